@@ -732,7 +732,7 @@ func getBGCoreAttributes() []string {
 
 func getBGUpdatableAttributes() []string {
 	attributes := [...]string{
-		"name", "owner_id", "entitlements_createenvironments", "entitlements_createsuborgs",
+		"name", "owner_id", "owner_username", "entitlements_createenvironments", "entitlements_createsuborgs",
 		"entitlements_globaldeployment", "entitlements_vcoresproduction_assigned", "entitlements_vcoressandbox_assigned",
 		"entitlements_vcoresdesign_assigned", "entitlements_vpcs_assigned", "entitlements_loadbalancer_assigned", "entitlements_vpns_assigned",
 	}
@@ -3,8 +3,6 @@ package anypoint
 import (
 	"context"
 	"io/ioutil"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -253,7 +251,7 @@ func dataSourceDLBsRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId(orgid, vpcid))
 
 	return diags
 }
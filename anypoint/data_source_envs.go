@@ -0,0 +1,214 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	env "github.com/mulesoft-consulting/anypoint-client-go/env"
+)
+
+func dataSourceENVs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceENVsRead,
+		Description: `
+		Reads all ` + "`" + `environments` + "`" + ` in your business group.
+		The environments can be filtered by type, production flag or name.
+		`,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The business group id",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only keep the environments of the given type, possible values: 'production', 'sandbox' or 'design'",
+				ValidateFunc: validation.StringInSlice(getENVTypes(), false),
+			},
+			"is_production": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only keep the production environments when true, the non production ones when false",
+			},
+			"name_regex": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only keep the environments whose name matches the given regular expression",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := regexp.Compile(val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%q must be a valid regular expression, got: %s", key, err))
+					}
+					return
+				},
+			},
+			"envs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of environments matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"organization_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_production": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"client_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"by_name": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The ids of the environments matching the filters indexed by name",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"len": {
+				Type:        schema.TypeInt,
+				Description: "The number of environments matching the filters",
+				Computed:    true,
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Description: "The total number of environments in the business group",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceENVsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	authctx := getENVAuthCtx(ctx, &pco)
+
+	//request envs
+	res, httpr, err := pco.envclient.DefaultApi.OrganizationsOrgIdEnvironmentsGet(authctx, orgid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to Get ENVs",
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+
+	//process data
+	data := filterENVsData(d, res.GetData())
+	envs := flattenENVsData(data)
+	byname := make(map[string]interface{})
+	for _, envitem := range data {
+		byname[envitem.GetName()] = envitem.GetId()
+	}
+
+	//save in data source schema
+	if err := d.Set("envs", envs); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set ENVs",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("by_name", byname); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set ENVs by name",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("len", len(envs)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set length of ENVs",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("total", res.GetTotal()); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set total number of ENVs",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	// always run
+	d.SetId(getDataSourceId(orgid, d.Get("type"), d.Get("is_production"), d.Get("name_regex")))
+
+	return diags
+}
+
+/*
+ Keeps only the environments matching the filters set in the data source schema
+*/
+func filterENVsData(d *schema.ResourceData, envs []env.Env) []env.Env {
+	envtype, filtertype := d.GetOk("type")
+	isprod, filterprod := d.GetOkExists("is_production")
+	var nameregex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameregex = regexp.MustCompile(v.(string))
+	}
+
+	res := make([]env.Env, 0, len(envs))
+	for _, envitem := range envs {
+		if filtertype && envitem.GetType() != envtype.(string) {
+			continue
+		}
+		if filterprod && envitem.GetIsProduction() != isprod.(bool) {
+			continue
+		}
+		if nameregex != nil && !nameregex.MatchString(envitem.GetName()) {
+			continue
+		}
+		res = append(res, envitem)
+	}
+	return res
+}
+
+/*
+ Transforms a list of environments to the dataSourceENVs schema
+*/
+func flattenENVsData(envs []env.Env) []interface{} {
+	res := make([]interface{}, len(envs))
+	for i, envitem := range envs {
+		res[i] = flattenENVData(&envitem)
+	}
+	return res
+}
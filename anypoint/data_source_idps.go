@@ -2,9 +2,8 @@ package anypoint
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -107,7 +106,7 @@ func dataSourceIDPsRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId(orgid))
 
 	return diags
 }
@@ -131,3 +130,37 @@ func flattenIDPsData(idps []idp.IdpSummary) []interface{} {
 	}
 	return result
 }
+
+/*
+ Checks that the given provider ids refer to identity providers of the given org
+*/
+func validateIdentityProviders(ctx context.Context, pco *ProviderConfOutput, orgid string, providerids []string) error {
+	if len(providerids) == 0 {
+		return nil
+	}
+
+	authctx := getIDPAuthCtx(ctx, pco)
+	res, httpr, err := pco.idpclient.DefaultApi.OrganizationsOrgIdIdentityProvidersGet(authctx, orgid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		return fmt.Errorf("unable to get the identity providers of org %s\n details: %s", orgid, details)
+	}
+	defer httpr.Body.Close()
+
+	idps := make(map[string]bool)
+	for _, item := range res.GetData() {
+		idps[item.GetProviderId()] = true
+	}
+	for _, providerid := range providerids {
+		if !idps[providerid] {
+			return fmt.Errorf("identity provider %s doesn't exist in org %s, use the anypoint_idps data source to list the identity providers", providerid, orgid)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mulesoft-consulting/anypoint-client-go/role"
+)
+
+func dataSourceRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRoleRead,
+		Description: `
+		Reads a specific ` + "`" + `role` + "`" + ` by its display name.
+		`,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The display name of the role, fails if several roles have this name",
+			},
+			"include_internal": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Include internal roles in the lookup",
+			},
+			"role_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"internal": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"org_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"namespaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"shareable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	name := d.Get("name").(string)
+
+	r, errDiags := searchUniqueRole(ctx, &pco, name, d.Get("include_internal").(bool))
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	//process data
+	roles := flattenRolesData(&[]role.Role{*r})
+	item := roles[0].(map[string]interface{})
+	//save in data source schema
+	for _, attr := range getRoleAttributes() {
+		if err := d.Set(attr, item[attr]); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to set role " + name + " attribute " + attr,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+
+	d.SetId(r.GetRoleId())
+
+	return diags
+}
+
+/*
+ Returns the only role having the given name.
+ Fails if no role or more than one role match
+*/
+func searchUniqueRole(ctx context.Context, pco *ProviderConfOutput, name string, includeInternal bool) (*role.Role, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	authctx := getRoleAuthCtx(ctx, pco)
+
+	req := pco.roleclient.DefaultApi.RolesGet(authctx).Name(name).IncludeInternal(includeInternal)
+	data, _, errDiags := fetchRolesPages(req, 0, defaultPageSize, true)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return nil, diags
+	}
+
+	roles := make([]role.Role, 0)
+	for _, r := range data {
+		if r.GetName() == name {
+			roles = append(roles, r)
+		}
+	}
+
+	if len(roles) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to find role " + name,
+			Detail:   "No role named " + name + " was found",
+		})
+		return nil, diags
+	}
+	if len(roles) > 1 {
+		ids := make([]string, len(roles))
+		for i, r := range roles {
+			ids[i] = r.GetRoleId()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Found multiple roles named " + name,
+			Detail:   "The roles " + strings.Join(ids, ", ") + " are all named " + name + ", use the role id instead",
+		})
+		return nil, diags
+	}
+
+	return &roles[0], diags
+}
+
+func getRoleAttributes() []string {
+	attributes := [...]string{
+		"role_id", "name", "description", "internal", "org_id", "namespaces", "shareable",
+	}
+	return attributes[:]
+}
+
+/*
+ Checks the given role assignments (role_id, org_id and env_id items) against the roles catalogue:
+ the roles must exist and the environments must belong to the business group of the assignment.
+ The catalogue doesn't expose the scope of the roles, an env_id given to a business group scoped role
+ (or missing for an environment scoped role) is only rejected by the platform, see getRoleScopeHint.
+ The given org id is used for the assignments without org_id
+*/
+func validateRoleAssignments(ctx context.Context, pco *ProviderConfOutput, orgid string, assignments []interface{}) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	authctx := getRoleAuthCtx(ctx, pco)
+	req := pco.roleclient.DefaultApi.RolesGet(authctx).IncludeInternal(true)
+	data, _, diags := fetchRolesPages(req, 0, defaultPageSize, true)
+	if diags.HasError() {
+		return fmt.Errorf("unable to load the roles catalogue\n details: %s", diags[0].Detail)
+	}
+	catalogue := make(map[string]role.Role)
+	for _, r := range data {
+		catalogue[r.GetRoleId()] = r
+	}
+
+	envs := make(map[string]bool)
+	envauthctx := getENVAuthCtx(ctx, pco)
+	for _, assignment := range assignments {
+		content := assignment.(map[string]interface{})
+		roleid, _ := content["role_id"].(string)
+		r, ok := catalogue[roleid]
+		if !ok {
+			return fmt.Errorf("role %s doesn't exist, use the anypoint_role data source to look roles up by name", roleid)
+		}
+		assignmentorgid, envid := getRoleAssignmentScope(content)
+		if envid == "" {
+			continue
+		}
+		if assignmentorgid == "" {
+			assignmentorgid = orgid
+		}
+		key := assignmentorgid + "/" + envid
+		if _, ok := envs[key]; ok {
+			continue
+		}
+		_, httpr, err := pco.envclient.DefaultApi.OrganizationsOrgIdEnvironmentsEnvironmentIdGet(envauthctx, assignmentorgid, envid).Execute()
+		if err != nil {
+			if httpr != nil && httpr.StatusCode == 404 {
+				return fmt.Errorf("role %s (%s) is assigned to environment %s which doesn't belong to business group %s", roleid, r.GetName(), envid, assignmentorgid)
+			}
+			return fmt.Errorf("unable to get environment %s of business group %s for role %s (%s)\n details: %s", envid, assignmentorgid, roleid, r.GetName(), err)
+		}
+		httpr.Body.Close()
+		envs[key] = true
+	}
+	return nil
+}
+
+/*
+ Returns a hint explaining how to scope role assignments, added to the errors returned by the platform
+*/
+func getRoleScopeHint() string {
+	return "Environment scoped roles require both org_id and env_id, business group scoped roles require org_id only and must not set env_id."
+}
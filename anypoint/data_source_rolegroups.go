@@ -2,9 +2,8 @@ package anypoint
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -105,6 +104,14 @@ func dataSourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, m int
 	//process data
 	data := res.GetData()
 	rolegroups := flattenRoleGroupsData(&data)
+	// the rolegroups api doesn't support pagination, warn when the results are partial
+	if len(data) < int(res.GetTotal()) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Partial list of rolegroups",
+			Detail:   fmt.Sprintf("Only %d out of %d rolegroups were returned for org %s", len(data), res.GetTotal(), orgid),
+		})
+	}
 	//save in data source schema
 	if err := d.Set("role_groups", rolegroups); err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -124,7 +131,7 @@ func dataSourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, m int
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId(orgid))
 
 	return diags
 }
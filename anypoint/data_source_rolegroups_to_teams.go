@@ -0,0 +1,387 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mulesoft-consulting/anypoint-client-go/role"
+	"github.com/mulesoft-consulting/anypoint-client-go/rolegroup"
+	"github.com/mulesoft-consulting/anypoint-client-go/user"
+)
+
+func dataSourceRoleGroupsToTeams() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRoleGroupsToTeamsRead,
+		Description: `
+		Reads the ` + "`" + `rolegroups` + "`" + ` of the business group with their roles and users, and describes the equivalent ` + "`" + `teams` + "`" + `.
+
+Each rolegroup is translated into a team, its roles into the team roles and its users into the team members.
+The ` + "`" + `config` + "`" + ` attribute contains the terraform configuration of the equivalent ` + "`" + `anypoint_team` + "`" + `, ` + "`" + `anypoint_team_roles` + "`" + ` and ` + "`" + `anypoint_team_members` + "`" + ` resources.
+See the "Migrating from rolegroups to teams" guide for the steps to follow in order to convert an existing configuration without losing access.
+		`,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"parent_team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the team under which the teams are created, usually the root team of the org",
+			},
+			"team_name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "A prefix added to the name of the rolegroups to name the teams",
+			},
+			"teams": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The teams equivalent to the rolegroups",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_group_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"team_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"external_names": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The external group names of the rolegroup, to be mapped to the team using anypoint_team_group_mappings",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"roles": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"role_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"org_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"member_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"config": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The terraform configuration of the teams equivalent to the rolegroups",
+			},
+		},
+	}
+}
+
+func dataSourceRoleGroupsToTeamsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	parentteamid := d.Get("parent_team_id").(string)
+	prefix := d.Get("team_name_prefix").(string)
+	authctx := getRoleGroupAuthCtx(ctx, &pco)
+
+	//request rolegroups
+	res, httpr, err := pco.rolegroupclient.DefaultApi.OrganizationsOrgIdRolegroupsGet(authctx, orgid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get rolegroups",
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	rolegroups := res.GetData()
+	if len(rolegroups) < int(res.GetTotal()) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Partial list of rolegroups",
+			Detail:   fmt.Sprintf("Only %d out of %d rolegroups were returned for org %s", len(rolegroups), res.GetTotal(), orgid),
+		})
+	}
+
+	//request the roles of each rolegroup
+	roles := make(map[string][]role.AssignedRole)
+	for _, rg := range rolegroups {
+		data, errDiags := getRoleGroupAssignedRoles(ctx, &pco, orgid, rg.GetRoleGroupId())
+		if errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+		roles[rg.GetRoleGroupId()] = data
+	}
+
+	//request the users of each rolegroup
+	members, errDiags := getRoleGroupsMembers(ctx, &pco, orgid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	//process data
+	teams := flattenRoleGroupsToTeamsData(orgid, rolegroups, roles, members, prefix)
+	//save in data source schema
+	if err := d.Set("teams", teams); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set teams",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("config", newRoleGroupsToTeamsConfig(orgid, parentteamid, teams)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set teams config",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	d.SetId(getDataSourceId(orgid, parentteamid, prefix))
+
+	return diags
+}
+
+/*
+ Returns the roles assigned to the given rolegroup
+*/
+func getRoleGroupAssignedRoles(ctx context.Context, pco *ProviderConfOutput, orgid string, rolegroupid string) ([]role.AssignedRole, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	authctx := getRoleAuthCtx(ctx, pco)
+
+	res, httpr, err := pco.roleclient.DefaultApi.OrganizationsOrgIdRolegroupsRolegroupIdRolesGet(authctx, orgid, rolegroupid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get rolegroup " + rolegroupid + " assigned roles",
+			Detail:   details,
+		})
+		return nil, diags
+	}
+	defer httpr.Body.Close()
+
+	return res.GetData(), diags
+}
+
+/*
+ Returns the ids of the users of each rolegroup of the given org, indexed by rolegroup id.
+ The rolegroups api doesn't list the users of a rolegroup, so the rolegroups of every user of the org are loaded
+*/
+func getRoleGroupsMembers(ctx context.Context, pco *ProviderConfOutput, orgid string) (map[string][]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	users, errDiags := searchUsers(ctx, pco, orgid, func(usr *user.User) bool { return true })
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return nil, diags
+	}
+
+	authctx := getUserRolegroupsAuthCtx(ctx, pco)
+	members := make(map[string][]string)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, maxConcurrentPageRequests)
+	for _, usr := range users {
+		wg.Add(1)
+		go func(userid string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			res, httpr, err := pco.userrgpclient.DefaultApi.OrganizationsOrgIdUsersUserIdRolegroupsGet(authctx, orgid, userid).Limit(500).Execute()
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				var details string
+				if httpr != nil {
+					b, _ := ioutil.ReadAll(httpr.Body)
+					details = string(b)
+				} else {
+					details = err.Error()
+				}
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to get user " + userid + " rolegroups",
+					Detail:   details,
+				})
+				return
+			}
+			defer httpr.Body.Close()
+			for _, rg := range res.GetData() {
+				members[rg.GetRoleGroupId()] = append(members[rg.GetRoleGroupId()], userid)
+			}
+		}(usr.GetId())
+	}
+	wg.Wait()
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, ids := range members {
+		sort.Strings(ids)
+	}
+	return members, diags
+}
+
+/*
+ Transforms the rolegroups, their roles and their users into the dataSourceRoleGroupsToTeams teams schema.
+ Roles without context org apply to the org of the role, or to the given org by default
+*/
+func flattenRoleGroupsToTeamsData(orgid string, rolegroups []rolegroup.Rolegroup, roles map[string][]role.AssignedRole, members map[string][]string, prefix string) []interface{} {
+	sort.SliceStable(rolegroups, func(i, j int) bool {
+		return rolegroups[i].GetName() < rolegroups[j].GetName()
+	})
+
+	res := make([]interface{}, len(rolegroups))
+	for i, rg := range rolegroups {
+		rolegroupid := rg.GetRoleGroupId()
+		item := make(map[string]interface{})
+		item["role_group_id"] = rolegroupid
+		item["team_name"] = prefix + rg.GetName()
+		item["external_names"] = rg.GetExternalNames()
+
+		teamroles := make([]interface{}, len(roles[rolegroupid]))
+		for j, r := range roles[rolegroupid] {
+			teamrole := make(map[string]interface{})
+			teamrole["role_id"] = r.GetRoleId()
+			teamrole["name"] = r.GetName()
+			params := r.GetContextParams()
+			roleorgid := params.GetOrg()
+			if roleorgid == "" {
+				roleorgid = r.GetOrgId()
+			}
+			if roleorgid == "" {
+				roleorgid = orgid
+			}
+			teamrole["org_id"] = roleorgid
+			teamroles[j] = teamrole
+		}
+		item["roles"] = teamroles
+
+		memberids := members[rolegroupid]
+		if memberids == nil {
+			memberids = make([]string, 0)
+		}
+		item["member_ids"] = memberids
+
+		res[i] = item
+	}
+	return res
+}
+
+/*
+ Generates the terraform configuration of the given teams (as returned by flattenRoleGroupsToTeamsData)
+*/
+func newRoleGroupsToTeamsConfig(orgid string, parentteamid string, teams []interface{}) string {
+	var b strings.Builder
+	names := make(map[string]bool)
+	for _, team := range teams {
+		item := team.(map[string]interface{})
+		name := newTerraformResourceName(item["team_name"].(string), names)
+
+		fmt.Fprintf(&b, "# rolegroup %s\n", item["role_group_id"])
+		fmt.Fprintf(&b, "resource \"anypoint_team\" %q {\n", name)
+		fmt.Fprintf(&b, "  org_id         = %s\n", hclString(orgid))
+		fmt.Fprintf(&b, "  parent_team_id = %s\n", hclString(parentteamid))
+		fmt.Fprintf(&b, "  team_name      = %s\n", hclString(item["team_name"].(string)))
+		fmt.Fprintf(&b, "  team_type      = \"internal\"\n")
+		fmt.Fprintf(&b, "}\n\n")
+
+		if roles := item["roles"].([]interface{}); len(roles) > 0 {
+			fmt.Fprintf(&b, "resource \"anypoint_team_roles\" %q {\n", name)
+			fmt.Fprintf(&b, "  org_id  = %s\n", hclString(orgid))
+			fmt.Fprintf(&b, "  team_id = anypoint_team.%s.id\n", name)
+			for _, r := range roles {
+				role := r.(map[string]interface{})
+				fmt.Fprintf(&b, "\n  roles {\n")
+				fmt.Fprintf(&b, "    role_id = %s    # %s\n", hclString(role["role_id"].(string)), role["name"])
+				fmt.Fprintf(&b, "    org_id  = %s\n", hclString(role["org_id"].(string)))
+				fmt.Fprintf(&b, "  }\n")
+			}
+			fmt.Fprintf(&b, "}\n\n")
+		}
+
+		if memberids := item["member_ids"].([]string); len(memberids) > 0 {
+			fmt.Fprintf(&b, "resource \"anypoint_team_members\" %q {\n", name)
+			fmt.Fprintf(&b, "  org_id  = %s\n", hclString(orgid))
+			fmt.Fprintf(&b, "  team_id = anypoint_team.%s.id\n", name)
+			for _, userid := range memberids {
+				fmt.Fprintf(&b, "\n  members {\n")
+				fmt.Fprintf(&b, "    user_id = %s\n", hclString(userid))
+				fmt.Fprintf(&b, "  }\n")
+			}
+			fmt.Fprintf(&b, "}\n\n")
+		}
+	}
+	return b.String()
+}
+
+/*
+ Returns a valid terraform resource name based on the given name and not already in the given set of names
+*/
+func newTerraformResourceName(name string, names map[string]bool) string {
+	base := strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "team_" + base
+	}
+	res := base
+	for i := 2; names[res]; i++ {
+		res = base + "_" + strconv.Itoa(i)
+	}
+	names[res] = true
+	return res
+}
+
+/*
+ Returns the given value as a quoted terraform string, escaping the template sequences
+*/
+func hclString(value string) string {
+	quoted := strconv.Quote(value)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
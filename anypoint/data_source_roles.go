@@ -2,9 +2,7 @@ package anypoint
 
 import (
 	"context"
-	"io/ioutil"
-	"strconv"
-	"time"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,6 +16,12 @@ func dataSourceRoles() *schema.Resource {
 		Reads all ` + "`" + `roles` + "`" + ` availabble.
 		`,
 		Schema: map[string]*schema.Schema{
+			"all_pages": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Loads all the pages of results starting at the given offset, the limit is then used as page size. When false a single page is loaded",
+			},
 			"params": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -136,25 +140,13 @@ func dataSourceRolesRead(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 
 	//request roles
-	res, httpr, err := req.Execute()
-	defer httpr.Body.Close()
-	if err != nil {
-		var details string
-		if httpr != nil {
-			b, _ := ioutil.ReadAll(httpr.Body)
-			details = string(b)
-		} else {
-			details = err.Error()
-		}
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to Get Roles",
-			Detail:   details,
-		})
+	offset, limit := getPageOpts(searchOpts)
+	data, total, errDiags := fetchRolesPages(req, offset, limit, d.Get("all_pages").(bool))
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
 		return diags
 	}
 	//process data
-	data := res.GetData()
 	roles := flattenRolesData(&data)
 	//save in data source schema
 	if err := d.Set("roles", roles); err != nil {
@@ -175,7 +167,7 @@ func dataSourceRolesRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diags
 	}
 
-	if err := d.Set("total", res.GetTotal()); err != nil {
+	if err := d.Set("total", total); err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to set total number roles",
@@ -184,7 +176,7 @@ func dataSourceRolesRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId("roles", d.Get("all_pages"), searchOpts))
 
 	return diags
 }
@@ -234,6 +226,27 @@ func parseRoleSearchOpts(req role.DefaultApiApiRolesGetRequest, params *schema.S
 	return req, diags
 }
 
+/*
+ Executes the given roles request for each page starting at the given offset.
+ Returns the roles of all the loaded pages and the total number of roles
+*/
+func fetchRolesPages(req role.DefaultApiApiRolesGetRequest, offset int32, limit int32, allPages bool) ([]role.Role, int32, diag.Diagnostics) {
+	pages, total, diags := fetchAllPages(offset, limit, allPages, "Unable to Get Roles", func(offset int32, limit int32) (interface{}, int, int32, *http.Response, error) {
+		res, httpr, err := req.Offset(offset).Limit(limit).Execute()
+		data := res.GetData()
+		return data, len(data), res.GetTotal(), httpr, err
+	})
+	if diags.HasError() {
+		return nil, 0, diags
+	}
+
+	roles := make([]role.Role, 0, total)
+	for _, page := range pages {
+		roles = append(roles, page.([]role.Role)...)
+	}
+	return roles, total, diags
+}
+
 /*
 * Transforms a set of roles to the dataSourceRoles schema
 * @param roles *[]role.Role the list of roles
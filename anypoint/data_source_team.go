@@ -4,8 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
-	"strconv"
-	"time"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,6 +17,7 @@ func dataSourceTeam() *schema.Resource {
 		ReadContext: dataSourceTeamRead,
 		Description: `
 		Reads a specific ` + "`" + `team` + "`" + ` in the business group.
+The team is looked up by its ` + "`" + `id` + "`" + ` or by its ` + "`" + `team_name` + "`" + `, optionally restricted to the children of a ` + "`" + `parent_team_id` + "`" + `.
 		`,
 		Schema: map[string]*schema.Schema{
 			"org_id": {
@@ -25,16 +25,29 @@ func dataSourceTeam() *schema.Resource {
 				Required: true,
 			},
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "team_name"},
+				Description:  "The id of the team",
 			},
 			"team_id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 			"team_name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "team_name"},
+				Description:  "The name of the team, fails if several teams have this name",
+			},
+			"parent_team_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "The id of the parent team, used to restrict the lookup by name to the children of this team",
 			},
 			"team_type": {
 				Type:     schema.TypeString,
@@ -42,7 +55,7 @@ func dataSourceTeam() *schema.Resource {
 			},
 			"ancestor_team_ids": {
 				Type:     schema.TypeList,
-				Optional: true,
+				Computed: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -66,6 +79,15 @@ func dataSourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface
 	teamid := d.Get("id").(string)
 	authctx := getTeamAuthCtx(ctx, &pco)
 
+	if teamid == "" {
+		t, errDiags := searchUniqueTeam(ctx, &pco, orgid, d.Get("team_name").(string), d.Get("parent_team_id").(string))
+		if errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+		teamid = t.GetTeamId()
+	}
+
 	//request roles
 	res, httpr, err := pco.teamclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdGet(authctx, orgid, teamid).Execute()
 	if err != nil {
@@ -95,12 +117,70 @@ func dataSourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface
 		})
 		return diags
 	}
+	if ancestors := res.GetAncestorTeamIds(); len(ancestors) > 0 {
+		d.Set("parent_team_id", ancestors[len(ancestors)-1])
+	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(teamid)
 
 	return diags
 }
 
+/*
+ Returns the only team of the given org having the given name.
+ When a parent team id is given, only the children of this team are considered.
+ Fails if no team or more than one team match
+*/
+func searchUniqueTeam(ctx context.Context, pco *ProviderConfOutput, orgid string, name string, parentid string) (*team.Team, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	authctx := getTeamAuthCtx(ctx, pco)
+
+	req := pco.teamclient.DefaultApi.OrganizationsOrgIdTeamsGet(authctx, orgid).Search(name)
+	if parentid != "" {
+		req = req.ParentTeamId([]string{parentid})
+	}
+	data, _, errDiags := fetchTeamsPages(req, 0, defaultPageSize, true)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return nil, diags
+	}
+
+	// the search is a partial match, only keeps the teams having exactly the given name
+	teams := make([]team.Team, 0)
+	for _, t := range data {
+		if t.GetTeamName() == name {
+			teams = append(teams, t)
+		}
+	}
+
+	scope := "org " + orgid
+	if parentid != "" {
+		scope = "team " + parentid
+	}
+	if len(teams) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to find team " + name,
+			Detail:   "No team named " + name + " was found in " + scope,
+		})
+		return nil, diags
+	}
+	if len(teams) > 1 {
+		ids := make([]string, len(teams))
+		for i, t := range teams {
+			ids[i] = t.GetTeamId()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Found multiple teams named " + name,
+			Detail:   "The teams " + strings.Join(ids, ", ") + " of " + scope + " are all named " + name + ", use the parent_team_id or the team id instead",
+		})
+		return nil, diags
+	}
+
+	return &teams[0], diags
+}
+
 func flattenTeamData(team *team.Team) map[string]interface{} {
 	item := make(map[string]interface{})
 	if team == nil {
@@ -3,8 +3,7 @@ package anypoint
 import (
 	"context"
 	"io/ioutil"
-	"strconv"
-	"time"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -129,7 +128,7 @@ func dataSourceTeamGroupMappingsRead(ctx context.Context, d *schema.ResourceData
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId(orgid, teamid, searchOpts))
 
 	return diags
 }
@@ -160,6 +159,27 @@ func parseTeamGroupMappingsSearchOpts(req team_group_mappings.DefaultApiApiOrgan
 	return req, diags
 }
 
+/*
+ Executes the given team group mappings request for each page starting at the given offset.
+ Returns the group mappings of all the loaded pages and the total number of group mappings
+*/
+func fetchTeamGroupMappingsPages(req team_group_mappings.DefaultApiApiOrganizationsOrgIdTeamsTeamIdGroupmappingsGetRequest, offset int32, limit int32, allPages bool) ([]team_group_mappings.TeamGroupMapping, int32, diag.Diagnostics) {
+	pages, total, diags := fetchAllPages(offset, limit, allPages, "Unable to get team groupmappings", func(offset int32, limit int32) (interface{}, int, int32, *http.Response, error) {
+		res, httpr, err := req.Offset(offset).Limit(limit).Execute()
+		data := res.GetData()
+		return data, len(data), res.GetTotal(), httpr, err
+	})
+	if diags.HasError() {
+		return nil, 0, diags
+	}
+
+	groupmappings := make([]team_group_mappings.TeamGroupMapping, 0, total)
+	for _, page := range pages {
+		groupmappings = append(groupmappings, page.([]team_group_mappings.TeamGroupMapping)...)
+	}
+	return groupmappings, total, diags
+}
+
 func flattenTeamGroupMappingsData(teamgroupmappings *[]team_group_mappings.TeamGroupMapping) []interface{} {
 	if teamgroupmappings != nil && len(*teamgroupmappings) > 0 {
 		res := make([]interface{}, len(*teamgroupmappings))
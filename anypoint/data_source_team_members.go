@@ -2,9 +2,7 @@ package anypoint
 
 import (
 	"context"
-	"io/ioutil"
-	"strconv"
-	"time"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -26,6 +24,12 @@ func dataSourceTeamMembers() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"all_pages": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Loads all the pages of results starting at the given offset, the limit is then used as page size. When false a single page is loaded",
+			},
 			"params": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -115,6 +119,11 @@ func dataSourceTeamMembers() *schema.Resource {
 					},
 				},
 			},
+			"len": {
+				Type:        schema.TypeInt,
+				Description: "The number of loaded results",
+				Computed:    true,
+			},
 			"total": {
 				Type:        schema.TypeInt,
 				Description: "The total number of available results",
@@ -140,25 +149,14 @@ func dataSourceTeamMembersRead(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	//request members
-	res, httpr, err := req.Execute()
-	if err != nil {
-		var details string
-		if httpr != nil {
-			b, _ := ioutil.ReadAll(httpr.Body)
-			details = string(b)
-		} else {
-			details = err.Error()
-		}
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to get team " + teamid + " member ",
-			Detail:   details,
-		})
+	offset, limit := getPageOpts(searchOpts)
+	data, total, errDiags := fetchTeamMembersPages(req, offset, limit, d.Get("all_pages").(bool))
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
 		return diags
 	}
-	defer httpr.Body.Close()
 	//process data
-	teammembers := flattenTeamMembersData(res.Data)
+	teammembers := flattenTeamMembersData(&data)
 	//save in data source schema
 	if err := d.Set("teammembers", teammembers); err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -169,7 +167,16 @@ func dataSourceTeamMembersRead(ctx context.Context, d *schema.ResourceData, m in
 		return diags
 	}
 
-	if err := d.Set("total", res.GetTotal); err != nil {
+	if err := d.Set("len", len(teammembers)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set length of team " + teamid + " members",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	if err := d.Set("total", total); err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to set total number of team " + teamid + " roles",
@@ -178,7 +185,7 @@ func dataSourceTeamMembersRead(ctx context.Context, d *schema.ResourceData, m in
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId(orgid, teamid, d.Get("all_pages"), searchOpts))
 
 	return diags
 }
@@ -205,7 +212,7 @@ func parseTeamMembersSearchOpts(req team_members.DefaultApiApiOrganizationsOrgId
 			continue
 		}
 		if k == "member_ids" {
-			req = req.MemberIds(v.([]string))
+			req = req.MemberIds(ListInterface2ListStrings(v.([]interface{})))
 			continue
 		}
 		if k == "search" {
@@ -233,6 +240,27 @@ func parseTeamMembersSearchOpts(req team_members.DefaultApiApiOrganizationsOrgId
 	return req, diags
 }
 
+/*
+ Executes the given teamMembers request for each page starting at the given offset.
+ Returns the teamMembers of all the loaded pages and the total number of teamMembers
+*/
+func fetchTeamMembersPages(req team_members.DefaultApiApiOrganizationsOrgIdTeamsTeamIdMembersGetRequest, offset int32, limit int32, allPages bool) ([]team_members.TeamMember, int32, diag.Diagnostics) {
+	pages, total, diags := fetchAllPages(offset, limit, allPages, "Unable to get team members", func(offset int32, limit int32) (interface{}, int, int32, *http.Response, error) {
+		res, httpr, err := req.Offset(offset).Limit(limit).Execute()
+		data := res.GetData()
+		return data, len(data), res.GetTotal(), httpr, err
+	})
+	if diags.HasError() {
+		return nil, 0, diags
+	}
+
+	teamMembers := make([]team_members.TeamMember, 0, total)
+	for _, page := range pages {
+		teamMembers = append(teamMembers, page.([]team_members.TeamMember)...)
+	}
+	return teamMembers, total, diags
+}
+
 func flattenTeamMembersData(teammembers *[]team_members.TeamMember) []interface{} {
 	if teammembers != nil && len(*teammembers) > 0 {
 		res := make([]interface{}, len(*teammembers))
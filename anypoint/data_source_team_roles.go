@@ -3,8 +3,7 @@ package anypoint
 import (
 	"context"
 	"io/ioutil"
-	"strconv"
-	"time"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -70,6 +69,14 @@ func dataSourceTeamRoles() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"org_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"env_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 						"context_params": {
 							Type:     schema.TypeMap,
 							Computed: true,
@@ -153,7 +160,7 @@ func dataSourceTeamRolesRead(ctx context.Context, d *schema.ResourceData, m inte
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId(orgid, teamid, searchOpts))
 
 	return diags
 }
@@ -192,6 +199,27 @@ func parseTeamRolesSearchOpts(req team_roles.DefaultApiApiOrganizationsOrgIdTeam
 	return req, diags
 }
 
+/*
+ Executes the given team roles request for each page starting at the given offset.
+ Returns the team roles of all the loaded pages and the total number of team roles
+*/
+func fetchTeamRolesPages(req team_roles.DefaultApiApiOrganizationsOrgIdTeamsTeamIdRolesGetRequest, offset int32, limit int32, allPages bool) ([]team_roles.TeamRole, int32, diag.Diagnostics) {
+	pages, total, diags := fetchAllPages(offset, limit, allPages, "Unable to get team roles", func(offset int32, limit int32) (interface{}, int, int32, *http.Response, error) {
+		res, httpr, err := req.Offset(offset).Limit(limit).Execute()
+		data := res.GetData()
+		return data, len(data), res.GetTotal(), httpr, err
+	})
+	if diags.HasError() {
+		return nil, 0, diags
+	}
+
+	teamRoles := make([]team_roles.TeamRole, 0, total)
+	for _, page := range pages {
+		teamRoles = append(teamRoles, page.([]team_roles.TeamRole)...)
+	}
+	return teamRoles, total, diags
+}
+
 func flattenTeamRolesData(roles *[]team_roles.TeamRole) []interface{} {
 	if roles != nil && len(*roles) > 0 {
 		res := make([]interface{}, len(*roles))
@@ -216,7 +244,9 @@ func flattenTeamRoleData(role *team_roles.TeamRole) map[string]interface{} {
 		item["role_id"] = *val
 	}
 	if val, ok := role.GetContextParamsOk(); ok {
+		item["org_id"] = val.GetOrg()
 		if env, ok := val.GetEnvIdOk(); ok {
+			item["env_id"] = *env
 			item["context_params"] = map[string]interface{}{
 				"org":   val.GetOrg(),
 				"envId": *env,
@@ -0,0 +1,214 @@
+package anypoint
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mulesoft-consulting/anypoint-client-go/team"
+)
+
+func dataSourceTeamTree() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTeamTreeRead,
+		Description: `
+		Reads the hierarchy of ` + "`" + `teams` + "`" + ` of the business group.
+
+The teams are returned as a flat list, each team referencing its parent and its children, and as a nested JSON document that can be decoded using the ` + "`" + `jsondecode` + "`" + ` function.
+		`,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"root_team_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The id of the team at the top of the returned hierarchy, by default the whole hierarchy of the org is returned",
+			},
+			"teams": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The teams of the hierarchy, parents are listed before their children",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"team_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"team_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"team_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_team_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ancestor_team_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"child_team_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"depth": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The depth of the team in the returned hierarchy, the top team has a depth of 0",
+						},
+					},
+				},
+			},
+			"tree": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The nested team structure as a JSON document, each team has a team_id, team_name, team_type and children attribute",
+			},
+		},
+	}
+}
+
+func dataSourceTeamTreeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	rootteamid := d.Get("root_team_id").(string)
+	authctx := getTeamAuthCtx(ctx, &pco)
+
+	//request teams
+	req := pco.teamclient.DefaultApi.OrganizationsOrgIdTeamsGet(authctx, orgid)
+	data, _, errDiags := fetchTeamsPages(req, 0, defaultPageSize, true)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	//process data
+	teams, tree := flattenTeamTreeData(data, rootteamid)
+	if rootteamid != "" && len(teams) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to find team " + rootteamid,
+			Detail:   "No team with id " + rootteamid + " was found in org " + orgid,
+		})
+		return diags
+	}
+	//save in data source schema
+	if err := d.Set("teams", teams); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set teams",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	b, err := json.Marshal(tree)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to encode team tree",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("tree", string(b)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set team tree",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	d.SetId(getDataSourceId(orgid, rootteamid))
+
+	return diags
+}
+
+/*
+ Builds the hierarchy of the given teams starting at the given root team (or at the teams without parent).
+ Returns the flat list of teams of the hierarchy (parents first) and the nested structure
+*/
+func flattenTeamTreeData(teams []team.Team, rootteamid string) ([]interface{}, []interface{}) {
+	byid := make(map[string]*team.Team)
+	children := make(map[string][]string)
+	roots := make([]string, 0)
+	for i := range teams {
+		t := &teams[i]
+		byid[t.GetTeamId()] = t
+	}
+	for _, t := range teams {
+		ancestors := t.GetAncestorTeamIds()
+		if len(ancestors) > 0 {
+			if _, ok := byid[ancestors[len(ancestors)-1]]; ok {
+				parentid := ancestors[len(ancestors)-1]
+				children[parentid] = append(children[parentid], t.GetTeamId())
+				continue
+			}
+		}
+		roots = append(roots, t.GetTeamId())
+	}
+	byname := func(ids []string) {
+		sort.SliceStable(ids, func(i, j int) bool {
+			return byid[ids[i]].GetTeamName() < byid[ids[j]].GetTeamName()
+		})
+	}
+	for _, ids := range children {
+		byname(ids)
+	}
+	byname(roots)
+	if rootteamid != "" {
+		roots = make([]string, 0)
+		if _, ok := byid[rootteamid]; ok {
+			roots = append(roots, rootteamid)
+		}
+	}
+
+	flat := make([]interface{}, 0)
+	var walk func(teamid string, depth int) map[string]interface{}
+	walk = func(teamid string, depth int) map[string]interface{} {
+		t := byid[teamid]
+		item := make(map[string]interface{})
+		item["team_id"] = t.GetTeamId()
+		item["team_name"] = t.GetTeamName()
+		item["team_type"] = t.GetTeamType()
+		ancestors := t.GetAncestorTeamIds()
+		if len(ancestors) > 0 {
+			item["parent_team_id"] = ancestors[len(ancestors)-1]
+		}
+		item["ancestor_team_ids"] = ancestors
+		item["child_team_ids"] = children[teamid]
+		item["depth"] = depth
+		flat = append(flat, item)
+
+		node := make(map[string]interface{})
+		node["team_id"] = t.GetTeamId()
+		node["team_name"] = t.GetTeamName()
+		node["team_type"] = t.GetTeamType()
+		nodes := make([]interface{}, 0, len(children[teamid]))
+		for _, childid := range children[teamid] {
+			nodes = append(nodes, walk(childid, depth+1))
+		}
+		node["children"] = nodes
+		return node
+	}
+
+	tree := make([]interface{}, 0, len(roots))
+	for _, teamid := range roots {
+		tree = append(tree, walk(teamid, 0))
+	}
+	return flat, tree
+}
@@ -2,9 +2,7 @@ package anypoint
 
 import (
 	"context"
-	"io/ioutil"
-	"strconv"
-	"time"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -23,6 +21,12 @@ func dataSourceTeams() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"all_pages": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Loads all the pages of results starting at the given offset, the limit is then used as page size. When false a single page is loaded",
+			},
 			"params": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -108,7 +112,7 @@ func dataSourceTeams() *schema.Resource {
 						},
 						"ancestor_team_ids": {
 							Type:     schema.TypeList,
-							Optional: true,
+							Computed: true,
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
@@ -152,26 +156,14 @@ func dataSourceTeamsRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diags
 	}
 
-	//request roles
-	res, httpr, err := req.Execute()
-	if err != nil {
-		var details string
-		if httpr != nil {
-			b, _ := ioutil.ReadAll(httpr.Body)
-			details = string(b)
-		} else {
-			details = err.Error()
-		}
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to get teams",
-			Detail:   details,
-		})
+	//request teams
+	offset, limit := getPageOpts(searchOpts)
+	data, total, errDiags := fetchTeamsPages(req, offset, limit, d.Get("all_pages").(bool))
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
 		return diags
 	}
-	defer httpr.Body.Close()
 	//process data
-	data := res.GetData()
 	teams := flattenTeamsData(&data)
 	//save in data source schema
 	if err := d.Set("teams", teams); err != nil {
@@ -191,7 +183,7 @@ func dataSourceTeamsRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diags
 	}
 
-	if err := d.Set("total", res.GetTotal()); err != nil {
+	if err := d.Set("total", total); err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to set total number of teams",
@@ -200,7 +192,7 @@ func dataSourceTeamsRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId(orgid, d.Get("all_pages"), searchOpts))
 
 	return diags
 }
@@ -215,11 +207,11 @@ func parseTeamSearchOpts(req team.DefaultApiApiOrganizationsOrgIdTeamsGetRequest
 
 	for k, v := range opts.(map[string]interface{}) {
 		if k == "ancestor_team_id" {
-			req = req.AncestorTeamId(v.([]string))
+			req = req.AncestorTeamId(ListInterface2ListStrings(v.([]interface{})))
 			continue
 		}
 		if k == "parent_team_id" {
-			req = req.ParentTeamId(v.([]string))
+			req = req.ParentTeamId(ListInterface2ListStrings(v.([]interface{})))
 			continue
 		}
 		if k == "team_id" {
@@ -255,6 +247,27 @@ func parseTeamSearchOpts(req team.DefaultApiApiOrganizationsOrgIdTeamsGetRequest
 	return req, diags
 }
 
+/*
+ Executes the given teams request for each page starting at the given offset.
+ Returns the teams of all the loaded pages and the total number of teams
+*/
+func fetchTeamsPages(req team.DefaultApiApiOrganizationsOrgIdTeamsGetRequest, offset int32, limit int32, allPages bool) ([]team.Team, int32, diag.Diagnostics) {
+	pages, total, diags := fetchAllPages(offset, limit, allPages, "Unable to get teams", func(offset int32, limit int32) (interface{}, int, int32, *http.Response, error) {
+		res, httpr, err := req.Offset(offset).Limit(limit).Execute()
+		data := res.GetData()
+		return data, len(data), res.GetTotal(), httpr, err
+	})
+	if diags.HasError() {
+		return nil, 0, diags
+	}
+
+	teams := make([]team.Team, 0, total)
+	for _, page := range pages {
+		teams = append(teams, page.([]team.Team)...)
+	}
+	return teams, total, diags
+}
+
 func flattenTeamsData(teams *[]team.Team) []interface{} {
 	if teams != nil && len(*teams) > 0 {
 		res := make([]interface{}, len(*teams))
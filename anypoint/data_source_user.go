@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,6 +17,7 @@ func dataSourceUser() *schema.Resource {
 		ReadContext: dataSourceUserRead,
 		Description: `
 		Reads a specific ` + "`" + `user` + "`" + ` in the business group.
+		The user can be looked up by ` + "`" + `id` + "`" + `, ` + "`" + `username` + "`" + ` or ` + "`" + `email` + "`" + `.
 		`,
 		Schema: map[string]*schema.Schema{
 			"org_id": {
@@ -25,8 +25,11 @@ func dataSourceUser() *schema.Resource {
 				Required: true,
 			},
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "username", "email"},
+				Description:  "The user id",
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
@@ -41,16 +44,22 @@ func dataSourceUser() *schema.Resource {
 				Computed: true,
 			},
 			"email": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "username", "email"},
+				Description:  "The user email, the lookup is case insensitive and fails if several users share the email",
 			},
 			"phone_number": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 			"username": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "username", "email"},
+				Description:  "The user username",
 			},
 			"idprovider_id": {
 				Type:     schema.TypeString,
@@ -130,6 +139,20 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface
 	userid := d.Get("id").(string)
 	authctx := getUserAuthCtx(ctx, &pco)
 
+	// look the user up when its id is not given
+	if userid == "" {
+		attr := "username"
+		if _, ok := d.GetOk("email"); ok {
+			attr = "email"
+		}
+		usr, errDiags := searchUniqueUser(ctx, &pco, orgid, attr, d.Get(attr).(string))
+		if errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+		userid = usr.GetId()
+	}
+
 	//request roles
 	res, httpr, err := pco.userclient.DefaultApi.OrganizationsOrgIdUsersUserIdGet(authctx, orgid, userid).Execute()
 	if err != nil {
@@ -160,7 +183,7 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(userid)
 
 	return diags
 }
@@ -0,0 +1,294 @@
+package anypoint
+
+import (
+	"context"
+	"io/ioutil"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mulesoft-consulting/anypoint-client-go/team_members"
+)
+
+func dataSourceUserEffectiveRoles() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUserEffectiveRolesRead,
+		Description: `
+		Reads all the ` + "`" + `roles` + "`" + ` granted to a ` + "`" + `user` + "`" + ` in the business group and where each one comes from.
+
+The roles are granted:
+* by the rolegroups of the user (source ` + "`" + `rolegroup` + "`" + `),
+* by the teams the user is a member of, and their ancestor teams (source ` + "`" + `team` + "`" + `),
+* by the teams the user is a member of through the identity provider group mappings, and their ancestor teams (source ` + "`" + `external_group` + "`" + `).
+		`,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The role bindings of the user, a role granted several times is listed once per source",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"org_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The business group to which the role applies",
+						},
+						"env_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The environment to which the role applies, for environment scoped roles",
+						},
+						"source": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "How the role is granted: rolegroup, team or external_group",
+						},
+						"source_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the rolegroup or of the team the role is assigned to",
+						},
+						"member_of_team_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "For roles granted by teams, the team the user is a member of",
+						},
+						"inherited": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the role is inherited from an ancestor of the team the user is a member of",
+						},
+					},
+				},
+			},
+			"len": {
+				Type:        schema.TypeInt,
+				Description: "The number of role bindings",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceUserEffectiveRolesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	userid := d.Get("user_id").(string)
+
+	roles := make([]map[string]interface{}, 0)
+
+	//roles granted by rolegroups
+	rgroles, errDiags := getUserRoleGroupsRoles(ctx, &pco, orgid, userid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+	roles = append(roles, rgroles...)
+
+	//roles granted by teams
+	teamroles, errDiags := getUserTeamsRoles(ctx, &pco, orgid, userid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+	roles = append(roles, teamroles...)
+
+	//process data
+	sort.SliceStable(roles, func(i, j int) bool {
+		if roles[i]["name"].(string) != roles[j]["name"].(string) {
+			return roles[i]["name"].(string) < roles[j]["name"].(string)
+		}
+		if roles[i]["source"].(string) != roles[j]["source"].(string) {
+			return roles[i]["source"].(string) < roles[j]["source"].(string)
+		}
+		return roles[i]["source_id"].(string) < roles[j]["source_id"].(string)
+	})
+	//save in data source schema
+	if err := d.Set("roles", roles); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set user " + userid + " effective roles",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("len", len(roles)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set length of user " + userid + " effective roles",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	d.SetId(getDataSourceId(orgid, userid))
+
+	return diags
+}
+
+/*
+ Returns the roles granted to the user by its rolegroups
+*/
+func getUserRoleGroupsRoles(ctx context.Context, pco *ProviderConfOutput, orgid string, userid string) ([]map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	authctx := getUserRolegroupsAuthCtx(ctx, pco)
+
+	res, httpr, err := pco.userrgpclient.DefaultApi.OrganizationsOrgIdUsersUserIdRolegroupsGet(authctx, orgid, userid).Limit(500).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get user " + userid + " rolegroups",
+			Detail:   details,
+		})
+		return nil, diags
+	}
+	defer httpr.Body.Close()
+
+	roles := make([]map[string]interface{}, 0)
+	for _, rg := range res.GetData() {
+		assigned, errDiags := getRoleGroupAssignedRoles(ctx, pco, orgid, rg.GetRoleGroupId())
+		if errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return nil, diags
+		}
+		for _, r := range assigned {
+			item := make(map[string]interface{})
+			item["role_id"] = r.GetRoleId()
+			item["name"] = r.GetName()
+			item["org_id"] = ""
+			if params, ok := r.GetContextParamsOk(); ok && params.Org != nil {
+				item["org_id"] = *params.Org
+			}
+			item["env_id"] = ""
+			item["source"] = "rolegroup"
+			item["source_id"] = rg.GetRoleGroupId()
+			item["member_of_team_id"] = ""
+			item["inherited"] = false
+			roles = append(roles, item)
+		}
+	}
+	return roles, diags
+}
+
+/*
+ Returns the roles granted to the user by the teams it is a member of, including the roles of their ancestor teams
+*/
+func getUserTeamsRoles(ctx context.Context, pco *ProviderConfOutput, orgid string, userid string) ([]map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	authctx := getTeamAuthCtx(ctx, pco)
+
+	req := pco.teamclient.DefaultApi.OrganizationsOrgIdTeamsGet(authctx, orgid)
+	teams, _, errDiags := fetchTeamsPages(req, 0, defaultPageSize, true)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return nil, diags
+	}
+
+	//look for the memberships of the user in every team
+	memberships := make(map[string]team_members.TeamMember)
+	membersauthctx := getTeamMembersAuthCtx(ctx, pco)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, maxConcurrentPageRequests)
+	for _, t := range teams {
+		wg.Add(1)
+		go func(teamid string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			res, httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersGet(membersauthctx, orgid, teamid).MemberIds([]string{userid}).Execute()
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				var details string
+				if httpr != nil {
+					b, _ := ioutil.ReadAll(httpr.Body)
+					details = string(b)
+				} else {
+					details = err.Error()
+				}
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to get team " + teamid + " members",
+					Detail:   details,
+				})
+				return
+			}
+			defer httpr.Body.Close()
+			for _, member := range res.GetData() {
+				if member.GetId() == userid {
+					memberships[teamid] = member
+				}
+			}
+		}(t.GetTeamId())
+	}
+	wg.Wait()
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	ancestors := make(map[string][]string)
+	for _, t := range teams {
+		ancestors[t.GetTeamId()] = t.GetAncestorTeamIds()
+	}
+
+	//the roles of the teams and of their ancestors
+	teamroles := make(map[string][]interface{})
+	roles := make([]map[string]interface{}, 0)
+	for teamid, member := range memberships {
+		source := "team"
+		if member.GetIsAssignedViaExternalGroups() {
+			source = "external_group"
+		}
+		chain := append([]string{teamid}, ancestors[teamid]...)
+		for _, sourceid := range chain {
+			if _, ok := teamroles[sourceid]; !ok {
+				live, errDiags := getTeamLiveRoles(ctx, pco, orgid, sourceid)
+				if errDiags.HasError() {
+					diags = append(diags, errDiags...)
+					return nil, diags
+				}
+				teamroles[sourceid] = live
+			}
+			for _, r := range teamroles[sourceid] {
+				content := r.(map[string]interface{})
+				item := make(map[string]interface{})
+				item["role_id"], _ = content["role_id"].(string)
+				item["name"], _ = content["name"].(string)
+				item["org_id"], _ = content["org_id"].(string)
+				item["env_id"], _ = content["env_id"].(string)
+				item["source"] = source
+				item["source_id"] = sourceid
+				item["member_of_team_id"] = teamid
+				item["inherited"] = sourceid != teamid
+				roles = append(roles, item)
+			}
+		}
+	}
+	return roles, diags
+}
@@ -3,8 +3,6 @@ package anypoint
 import (
 	"context"
 	"io/ioutil"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -175,7 +173,7 @@ func dataSourceUserRolegroupsRead(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId(orgid, userid, searchOpts))
 
 	return diags
 }
@@ -2,9 +2,8 @@ package anypoint
 
 import (
 	"context"
-	"io/ioutil"
-	"strconv"
-	"time"
+	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -22,6 +21,12 @@ func dataSourceUsers() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"all_pages": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Loads all the pages of results starting at the given offset, the limit is then used as page size. When false a single page is loaded",
+			},
 			"params": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -166,26 +171,14 @@ func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diags
 	}
 
-	//request roles
-	res, httpr, err := req.Execute()
-	if err != nil {
-		var details string
-		if httpr != nil {
-			b, _ := ioutil.ReadAll(httpr.Body)
-			details = string(b)
-		} else {
-			details = err.Error()
-		}
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to get users",
-			Detail:   details,
-		})
+	//request users
+	offset, limit := getPageOpts(searchOpts)
+	data, total, errDiags := fetchUsersPages(req, offset, limit, d.Get("all_pages").(bool))
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
 		return diags
 	}
-	defer httpr.Body.Close()
 	//process data
-	data := res.GetData()
 	users := flattenUsersData(&data)
 	//save in data source schema
 	if err := d.Set("users", users); err != nil {
@@ -205,7 +198,7 @@ func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diags
 	}
 
-	if err := d.Set("total", res.GetTotal()); err != nil {
+	if err := d.Set("total", total); err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to set total number of users",
@@ -214,7 +207,7 @@ func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diags
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId(orgid, d.Get("all_pages"), searchOpts))
 
 	return diags
 }
@@ -263,3 +256,90 @@ func flattenUsersData(users *[]user.User) []interface{} {
 	}
 	return res
 }
+
+/*
+ Walks through all the users of the given org and returns the ones for which match returns true
+*/
+func searchUsers(ctx context.Context, pco *ProviderConfOutput, orgid string, match func(usr *user.User) bool) ([]user.User, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	authctx := getUserAuthCtx(ctx, pco)
+
+	req := pco.userclient.DefaultApi.OrganizationsOrgIdUsersGet(authctx, orgid)
+	data, _, errDiags := fetchUsersPages(req, 0, 500, true)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return nil, diags
+	}
+
+	users := make([]user.User, 0)
+	for i := range data {
+		if match(&data[i]) {
+			users = append(users, data[i])
+		}
+	}
+
+	return users, diags
+}
+
+/*
+ Executes the given users request for each page starting at the given offset.
+ Returns the users of all the loaded pages and the total number of users
+*/
+func fetchUsersPages(req user.DefaultApiApiOrganizationsOrgIdUsersGetRequest, offset int32, limit int32, allPages bool) ([]user.User, int32, diag.Diagnostics) {
+	pages, total, diags := fetchAllPages(offset, limit, allPages, "Unable to get users", func(offset int32, limit int32) (interface{}, int, int32, *http.Response, error) {
+		res, httpr, err := req.Offset(offset).Limit(limit).Execute()
+		data := res.GetData()
+		return data, len(data), res.GetTotal(), httpr, err
+	})
+	if diags.HasError() {
+		return nil, 0, diags
+	}
+
+	users := make([]user.User, 0, total)
+	for _, page := range pages {
+		users = append(users, page.([]user.User)...)
+	}
+	return users, total, diags
+}
+
+/*
+ Returns the only user of the given org having the given attribute (username or email) set to the given value.
+ Fails if no user or more than one user match
+*/
+func searchUniqueUser(ctx context.Context, pco *ProviderConfOutput, orgid string, attr string, value string) (*user.User, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	users, errDiags := searchUsers(ctx, pco, orgid, func(usr *user.User) bool {
+		if attr == "email" {
+			return strings.EqualFold(usr.GetEmail(), value)
+		}
+		return usr.GetUsername() == value
+	})
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return nil, diags
+	}
+
+	if len(users) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to find user with " + attr + " " + value,
+			Detail:   "No user with " + attr + " " + value + " was found in org " + orgid,
+		})
+		return nil, diags
+	}
+	if len(users) > 1 {
+		ids := make([]string, len(users))
+		for i, usr := range users {
+			ids[i] = usr.GetId()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Found multiple users with " + attr + " " + value,
+			Detail:   "The users " + strings.Join(ids, ", ") + " of org " + orgid + " all have the " + attr + " " + value + ", use the user id instead",
+		})
+		return nil, diags
+	}
+
+	return &users[0], diags
+}
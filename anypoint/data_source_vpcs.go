@@ -3,8 +3,6 @@ package anypoint
 import (
 	"context"
 	"io/ioutil"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -169,7 +167,7 @@ func dataSourceVPCsRead(ctx context.Context, d *schema.ResourceData, m interface
 	}
 
 	// always run
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(getDataSourceId(orgid))
 
 	return diags
 }
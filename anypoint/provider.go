@@ -78,35 +78,44 @@ func Provider() *schema.Provider {
 			"anypoint_env":                 resourceENV(),
 			"anypoint_user":                resourceUser(),
 			"anypoint_user_rolegroup":      resourceUserRolegroup(),
+			"anypoint_users_bulk":          resourceUsersBulk(),
 			"anypoint_team":                resourceTeam(),
 			"anypoint_team_roles":          resourceTeamRoles(),
+			"anypoint_team_role":           resourceTeamRole(),
 			"anypoint_team_member":         resourceTeamMember(),
+			"anypoint_team_members":        resourceTeamMembers(),
 			"anypoint_team_group_mappings": resourceTeamGroupMappings(),
+			"anypoint_team_group_mapping":  resourceTeamGroupMapping(),
 			"anypoint_dlb":                 resourceDLB(),
 			"anypoint_idp_oidc":            resourceOIDC(),
 			"anypoint_idp_saml":            resourceSAML(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"anypoint_vpcs":                dataSourceVPCs(),
-			"anypoint_vpc":                 dataSourceVPC(),
-			"anypoint_bg":                  dataSourceBG(),
-			"anypoint_roles":               dataSourceRoles(),
-			"anypoint_rolegroup":           dataSourceRoleGroup(),
-			"anypoint_rolegroups":          dataSourceRoleGroups(),
-			"anypoint_users":               dataSourceUsers(),
-			"anypoint_user":                dataSourceUser(),
-			"anypoint_env":                 dataSourceENV(),
-			"anypoint_user_rolegroup":      dataSourceUserRolegroup(),
-			"anypoint_user_rolegroups":     dataSourceUserRolegroups(),
-			"anypoint_team":                dataSourceTeam(),
-			"anypoint_teams":               dataSourceTeams(),
-			"anypoint_team_roles":          dataSourceTeamRoles(),
-			"anypoint_team_members":        dataSourceTeamMembers(),
-			"anypoint_team_group_mappings": dataSourceTeamGroupMappings(),
-			"anypoint_dlb":                 dataSourceDLB(),
-			"anypoint_dlbs":                dataSourceDLBs(),
-			"anypoint_idp":                 dataSourceIDP(),
-			"anypoint_idps":                dataSourceIDPs(),
+			"anypoint_vpcs":                 dataSourceVPCs(),
+			"anypoint_vpc":                  dataSourceVPC(),
+			"anypoint_bg":                   dataSourceBG(),
+			"anypoint_roles":                dataSourceRoles(),
+			"anypoint_role":                 dataSourceRole(),
+			"anypoint_rolegroup":            dataSourceRoleGroup(),
+			"anypoint_rolegroups":           dataSourceRoleGroups(),
+			"anypoint_rolegroups_to_teams":  dataSourceRoleGroupsToTeams(),
+			"anypoint_users":                dataSourceUsers(),
+			"anypoint_user":                 dataSourceUser(),
+			"anypoint_user_effective_roles": dataSourceUserEffectiveRoles(),
+			"anypoint_env":                  dataSourceENV(),
+			"anypoint_envs":                 dataSourceENVs(),
+			"anypoint_user_rolegroup":       dataSourceUserRolegroup(),
+			"anypoint_user_rolegroups":      dataSourceUserRolegroups(),
+			"anypoint_team":                 dataSourceTeam(),
+			"anypoint_teams":                dataSourceTeams(),
+			"anypoint_team_tree":            dataSourceTeamTree(),
+			"anypoint_team_roles":           dataSourceTeamRoles(),
+			"anypoint_team_members":         dataSourceTeamMembers(),
+			"anypoint_team_group_mappings":  dataSourceTeamGroupMappings(),
+			"anypoint_dlb":                  dataSourceDLB(),
+			"anypoint_dlbs":                 dataSourceDLBs(),
+			"anypoint_idp":                  dataSourceIDP(),
+			"anypoint_idps":                 dataSourceIDPs(),
 		},
 		ConfigureContextFunc: providerConfigure,
 		TerraformVersion:     "v1.0.1",
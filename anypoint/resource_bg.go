@@ -17,8 +17,11 @@ func resourceBG() *schema.Resource {
 		ReadContext:   resourceBGRead,
 		UpdateContext: resourceBGUpdate,
 		DeleteContext: resourceBGDelete,
+		CustomizeDiff: resourceBGCustomizeDiff,
 		Description: `
 		Creates a business group (org).
+		The owner can be given either by id using ` + "`" + `owner_id` + "`" + ` or by username using ` + "`" + `owner_username` + "`" + `.
+		The owner must be a member of the parent organization, changing it transfers the ownership of the business group.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -35,8 +38,11 @@ func resourceBG() *schema.Resource {
 				Required: true,
 			},
 			"owner_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"owner_id", "owner_username"},
+				Description:  "The id of the business group owner. Conflicts with owner_username.",
 			},
 			"created_at": {
 				Type:     schema.TypeString,
@@ -447,8 +453,11 @@ func resourceBG() *schema.Resource {
 				Optional: true,
 			},
 			"owner_username": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"owner_id", "owner_username"},
+				Description:  "The username of the business group owner, resolved among the users of the parent organization. Conflicts with owner_id.",
 			},
 			"owner_idprovider_id": {
 				Type:     schema.TypeString,
@@ -483,6 +492,12 @@ func resourceBG() *schema.Resource {
 				Optional: true,
 				Default:  60,
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true the business group cannot be deleted",
+			},
 		},
 	}
 }
@@ -493,7 +508,15 @@ func resourceBGCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 	pco := m.(ProviderConfOutput)
 
 	authctx := getBGAuthCtx(ctx, &pco)
-	body := newBGPostBody(d)
+	parentorgid := d.Get("parent_organization_id").(string)
+
+	ownerid, errDiags := resolveBGOwnerId(ctx, &pco, parentorgid, d)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	body := newBGPostBody(d, ownerid)
 
 	res, httpr, err := pco.orgclient.DefaultApi.OrganizationsPost(authctx).BGPostReqBody(*body).Execute()
 	if err != nil {
@@ -565,9 +588,21 @@ func resourceBGUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 	orgid := d.Id()
 
 	authctx := getBGAuthCtx(ctx, &pco)
+	ownerid := d.Get("owner_id").(string)
+	ownerChanged := d.HasChanges("owner_id", "owner_username")
+
+	if ownerChanged {
+		parentorgid := d.Get("parent_organization_id").(string)
+		var errDiags diag.Diagnostics
+		ownerid, errDiags = resolveBGOwnerId(ctx, &pco, parentorgid, d)
+		if errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+	}
 
 	if d.HasChanges(getBGUpdatableAttributes()...) {
-		body := newBGPutBody(d)
+		body := newBGPutBody(d, ownerid)
 		_, httpr, err := pco.orgclient.DefaultApi.OrganizationsOrgIdPut(authctx, orgid).BGPutReqBody(*body).Execute()
 		if err != nil {
 			var details string
@@ -588,6 +623,13 @@ func resourceBGUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 		d.Set("last_updated", time.Now().Format(time.RFC850))
 	}
 
+	if ownerChanged {
+		if errDiags := verifyBGOwnershipTransfer(authctx, &pco, orgid, ownerid); errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+	}
+
 	return resourceBGRead(ctx, d, m)
 }
 
@@ -599,6 +641,15 @@ func resourceBGDelete(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	authctx := getBGAuthCtx(ctx, &pco)
 
+	if d.Get("deletion_protection").(bool) {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to Delete Business Group",
+			Detail:   "Deletion protection is enabled for business group " + orgid + ", set deletion_protection to false and apply before deleting it.",
+		})
+		return diags
+	}
+
 	_, httpr, err := pco.orgclient.DefaultApi.OrganizationsOrgIdDelete(authctx, orgid).Execute()
 	if err != nil {
 		var details string
@@ -626,11 +677,11 @@ func resourceBGDelete(ctx context.Context, d *schema.ResourceData, m interface{}
 /*
  * Creates body for B.G POST request
  */
-func newBGPostBody(d *schema.ResourceData) *org.BGPostReqBody {
+func newBGPostBody(d *schema.ResourceData, ownerid string) *org.BGPostReqBody {
 	body := org.NewBGPostReqBodyWithDefaults()
 
 	body.SetName(d.Get("name").(string))
-	body.SetOwnerId(d.Get("owner_id").(string))
+	body.SetOwnerId(ownerid)
 	body.SetParentOrganizationId((d.Get("parent_organization_id").(string)))
 	body.SetEntitlements(*newEntitlementsFromD(d))
 
@@ -640,10 +691,10 @@ func newBGPostBody(d *schema.ResourceData) *org.BGPostReqBody {
 /*
  * Creates body for B.G PUT request
  */
-func newBGPutBody(d *schema.ResourceData) *org.BGPutReqBody {
+func newBGPutBody(d *schema.ResourceData, ownerid string) *org.BGPutReqBody {
 	body := org.NewBGPutReqBodyWithDefaults()
 	body.SetName(d.Get("name").(string))
-	body.SetOwnerId(d.Get("owner_id").(string))
+	body.SetOwnerId(ownerid)
 	body.SetEntitlements(*newEntitlementsFromD(d))
 	body.SetSessionTimeout(int32(d.Get("session_timeout").(int)))
 
@@ -684,6 +735,98 @@ func newEntitlementsFromD(d *schema.ResourceData) *org.EntitlementsCore {
 	return entitlements
 }
 
+/*
+ * Marks the owner attribute that is not set by the user as computed whenever the owner changes,
+ * the value is known only after the ownership transfer
+ */
+func resourceBGCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("owner_username") {
+		return d.SetNewComputed("owner_id")
+	}
+	if d.HasChange("owner_id") {
+		return d.SetNewComputed("owner_username")
+	}
+	return nil
+}
+
+/*
+ * Returns the id of the B.G owner, the owner is looked up by username when its id is not known.
+ * Fails if the owner is not a member of the given parent org
+ */
+func resolveBGOwnerId(ctx context.Context, pco *ProviderConfOutput, parentorgid string, d *schema.ResourceData) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ownerid := d.Get("owner_id").(string)
+
+	if ownerid == "" {
+		username := d.Get("owner_username").(string)
+		usr, errDiags := searchUniqueUser(ctx, pco, parentorgid, "username", username)
+		if errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return "", diags
+		}
+		return usr.GetId(), diags
+	}
+
+	authctx := getUserAuthCtx(ctx, pco)
+	_, httpr, err := pco.userclient.DefaultApi.OrganizationsOrgIdUsersUserIdGet(authctx, parentorgid, ownerid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Business Group owner " + ownerid + " is not a member of the parent organization " + parentorgid,
+			Detail:   details,
+		})
+		return "", diags
+	}
+	defer httpr.Body.Close()
+
+	return ownerid, diags
+}
+
+/*
+ * Checks that the ownership of the B.G has been transferred to the given owner
+ */
+func verifyBGOwnershipTransfer(authctx context.Context, pco *ProviderConfOutput, orgid string, ownerid string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	res, httpr, err := pco.orgclient.DefaultApi.OrganizationsOrgIdGet(authctx, orgid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to Get Business Group",
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+
+	if res.GetOwnerId() != ownerid {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to Transfer Business Group Ownership",
+			Detail:   "The owner of business group " + orgid + " is still " + res.GetOwnerId() + " instead of " + ownerid,
+		})
+	}
+
+	return diags
+}
+
 /*
  * Returns authentication context (includes authorization header)
  */
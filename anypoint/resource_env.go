@@ -2,11 +2,13 @@ package anypoint
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	env "github.com/mulesoft-consulting/anypoint-client-go/env"
 )
@@ -17,8 +19,10 @@ func resourceENV() *schema.Resource {
 		ReadContext:   resourceENVRead,
 		UpdateContext: resourceENVUpdate,
 		DeleteContext: resourceENVDelete,
+		CustomizeDiff: resourceENVCustomizeDiff,
 		Description: `
 		Creates an ` + "`" + `environement` + "`" + ` for your ` + "`" + `org` + "`" + `.
+		The environment name and ` + "`" + `type` + "`" + ` can be updated in place, the environment is never recreated.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -34,23 +38,32 @@ func resourceENV() *schema.Resource {
 				Computed: true,
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment name, can be updated in place",
 			},
 			"is_production": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				ForceNew: true,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the environment is a production one, must be consistent with the environment type",
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The environment type, possible values: 'production', 'sandbox' or 'design'. Can be updated in place",
+				ValidateFunc: validation.StringInSlice(getENVTypes(), false),
 			},
 			"client_id": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "When true the environment cannot be deleted. Defaults to true for production environments and false otherwise",
+			},
 		},
 	}
 }
@@ -63,6 +76,10 @@ func resourceENVCreate(ctx context.Context, d *schema.ResourceData, m interface{
 
 	authctx := getENVAuthCtx(ctx, &pco)
 
+	if _, ok := d.GetOkExists("deletion_protection"); !ok {
+		d.Set("deletion_protection", d.Get("type").(string) == "production")
+	}
+
 	body := newENVPostBody(d)
 
 	//request env creation
@@ -128,6 +145,10 @@ func resourceENVRead(ctx context.Context, d *schema.ResourceData, m interface{})
 		})
 		return diags
 	}
+	// imported environments get the default deletion protection
+	if _, ok := d.GetOkExists("deletion_protection"); !ok {
+		d.Set("deletion_protection", res.GetIsProduction())
+	}
 
 	return diags
 }
@@ -142,8 +163,8 @@ func resourceENVUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 
 	if d.HasChanges(getENVCoreAttributes()...) {
 		body := newENVPutBody(d)
-		//request env creation
-		_, httpr, err := pco.envclient.DefaultApi.OrganizationsOrgIdEnvironmentsEnvironmentIdPut(authctx, orgid, envid).EnvCore(*body).Execute()
+		//request env update
+		res, httpr, err := pco.envclient.DefaultApi.OrganizationsOrgIdEnvironmentsEnvironmentIdPut(authctx, orgid, envid).EnvCore(*body).Execute()
 		if err != nil {
 			var details string
 			if httpr != nil {
@@ -160,6 +181,15 @@ func resourceENVUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 			return diags
 		}
 		defer httpr.Body.Close()
+		// the type is updated in place, don't let a refused change go unnoticed
+		if envtype := d.Get("type").(string); res.GetType() != envtype {
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to Update ENV",
+				Detail:   fmt.Sprintf("the type of environment %s is still %q after the update to %q", envid, res.GetType(), envtype),
+			})
+			return diags
+		}
 
 		d.Set("last_updated", time.Now().Format(time.RFC850))
 	}
@@ -176,6 +206,15 @@ func resourceENVDelete(ctx context.Context, d *schema.ResourceData, m interface{
 
 	authctx := getENVAuthCtx(ctx, &pco)
 
+	if d.Get("deletion_protection").(bool) {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to Delete ENV",
+			Detail:   "Deletion protection is enabled for environment " + envid + ", set deletion_protection to false and apply before deleting it.",
+		})
+		return diags
+	}
+
 	httpr, err := pco.envclient.DefaultApi.OrganizationsOrgIdEnvironmentsEnvironmentIdDelete(authctx, orgid, envid).Execute()
 	if err != nil {
 		var details string
@@ -218,9 +257,38 @@ func newENVPutBody(d *schema.ResourceData) *env.EnvCore {
 	body := env.NewEnvCoreWithDefaults()
 
 	body.SetName(d.Get("name").(string))
+	body.SetType(d.Get("type").(string))
 	return body
 }
 
+/*
+ * Checks that is_production is consistent with the environment type at plan time.
+ */
+func resourceENVCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	envtype := d.Get("type").(string)
+	if isprod, ok := d.GetOkExists("is_production"); ok && d.HasChange("is_production") {
+		if isprod.(bool) != (envtype == "production") {
+			return fmt.Errorf("is_production is %t but the environment type is %q, is_production must be true only for production environments", isprod.(bool), envtype)
+		}
+	} else if d.Id() != "" && d.HasChange("type") {
+		// the type is updated in place, is_production follows it
+		if err := d.SetNew("is_production", envtype == "production"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * Returns the supported environment types
+ */
+func getENVTypes() []string {
+	types := [...]string{
+		"production", "sandbox", "design",
+	}
+	return types[:]
+}
+
 /*
  * Returns authentication context (includes authorization header)
  */
@@ -15,11 +15,15 @@ func resourceRoleGroupRoles() *schema.Resource {
 		CreateContext: resourceRoleGroupRolesCreate,
 		ReadContext:   resourceRoleGroupRolesRead,
 		DeleteContext: resourceRoleGroupRolesDelete,
+		CustomizeDiff: resourceRoleGroupRolesCustomizeDiff,
 		DeprecationMessage: `
 		This resource is deprecated, please use ` + "`" + `teams` + "`" + `, ` + "`" + `team_members` + "`" + `team_roles` + "`" + ` instead.
 		`,
 		Description: `
 		Assignes ` + "`" + `roles` + "`" + ` to a ` + "`" + `rolegroup` + "`" + ` for your ` + "`" + `org` + "`" + `.
+
+Environment scoped roles require the ` + "`" + `env_id` + "`" + ` of the role assignment, the existence of the roles and the business group of the environments are checked at plan time.
+The roles catalogue doesn't tell which roles are environment scoped, so a role given the wrong scope is only rejected by the platform when applying.
 		`,
 		Schema: map[string]*schema.Schema{
 			"role_group_id": {
@@ -68,8 +72,17 @@ func resourceRoleGroupRoles() *schema.Resource {
 							ForceNew: true,
 						},
 						"org_id": {
-							Type:     schema.TypeString,
-							Computed: true,
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							ForceNew:    true,
+							Description: "The business group to which the role applies, by default the org of the rolegroup",
+						},
+						"env_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The environment to which the role applies, only for environment scoped roles",
 						},
 						"name": {
 							Type:     schema.TypeString,
@@ -90,6 +103,14 @@ func resourceRoleGroupRoles() *schema.Resource {
 	}
 }
 
+func resourceRoleGroupRolesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("roles") || !d.NewValueKnown("roles") {
+		return nil
+	}
+	pco := m.(ProviderConfOutput)
+	return validateRoleAssignments(ctx, &pco, d.Get("org_id").(string), d.Get("roles").([]interface{}))
+}
+
 func resourceRoleGroupRolesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -118,7 +139,7 @@ func resourceRoleGroupRolesCreate(ctx context.Context, d *schema.ResourceData, m
 		diags := append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to assign roles to rolegroup",
-			Detail:   details,
+			Detail:   details + "\n" + getRoleScopeHint(),
 		})
 		return diags
 	}
@@ -164,6 +185,15 @@ func resourceRoleGroupRolesRead(ctx context.Context, d *schema.ResourceData, m i
 		diags = append(diags, errDiags...)
 		return diags
 	}
+	// the environment of the assignments isn't returned by the platform, it is kept from the state
+	envs := make(map[string]interface{})
+	for _, role := range d.Get("roles").([]interface{}) {
+		content := role.(map[string]interface{})
+		envs[content["role_id"].(string)+"|"+content["org_id"].(string)] = content["env_id"]
+	}
+	for _, assigned_role := range assigned_roles {
+		assigned_role["env_id"] = envs[assigned_role["role_id"].(string)+"|"+assigned_role["org_id"].(string)]
+	}
 	//save in data source schema
 	if err := setAssignedRolesAttributesToResourceData(d, assigned_roles); err != nil {
 		diags := append(diags, diag.Diagnostic{
@@ -237,11 +267,13 @@ func newRolegroupRolesPostBody(org_id string, rolegroup_id string, d *schema.Res
 	}
 	res := make([]map[string]interface{}, len(roles))
 	for i, role := range roles {
+		content := role.(map[string]interface{})
 		item := make(map[string]interface{})
-		item["role_id"] = role.(map[string]interface{})["role_id"].(string)
-		item["context_params"] = map[string]string{
-			"org": org_id,
+		item["role_id"] = content["role_id"].(string)
+		if orgid, ok := content["org_id"].(string); !ok || orgid == "" {
+			content["org_id"] = org_id
 		}
+		item["context_params"] = newRoleContextParams(content)
 		res[i] = item
 	}
 	return res, diags
@@ -261,9 +293,11 @@ func newRolegroupRolesDeleteBody(d *schema.ResourceData) ([]map[string]interface
 	for i, role := range roles {
 		item := make(map[string]interface{})
 		item["role_id"] = role.(map[string]interface{})["role_id"]
-		item["context_params"] = map[string]string{
-			"org": role.(map[string]interface{})["context_params"].(map[string]interface{})["org"].(string),
+		content := map[string]interface{}{
+			"org_id": role.(map[string]interface{})["context_params"].(map[string]interface{})["org"].(string),
+			"env_id": role.(map[string]interface{})["env_id"],
 		}
+		item["context_params"] = newRoleContextParams(content)
 		item["role_group_assignment_id"] = role.(map[string]interface{})["role_group_assignment_id"]
 		item["role_group_id"] = role.(map[string]interface{})["role_group_id"]
 		res[i] = item
@@ -279,14 +313,19 @@ func flattenRoleGroupRolesData(assigned_roles []role.AssignedRole) ([]map[string
 
 		for i, role := range assigned_roles {
 			item := make(map[string]interface{})
+			params := role.GetContextParams()
+			orgid := params.GetOrg()
+			if orgid == "" {
+				orgid = role.GetOrgId()
+			}
 			item["context_params"] = map[string]string{
-				"org": *role.GetContextParams().Org,
+				"org": orgid,
 			}
 			item["created_at"] = role.GetCreatedAt()
 			item["role_group_assignment_id"] = role.GetRoleGroupAssignmentId()
 			item["role_group_id"] = role.GetRoleGroupId()
 			item["role_id"] = role.GetRoleId()
-			item["org_id"] = role.GetOrgId()
+			item["org_id"] = orgid
 			item["name"] = role.GetName()
 			item["description"] = role.GetDescription()
 			item["internal"] = role.GetInternal()
@@ -329,7 +368,7 @@ func setAssignedRolesAttributesToResourceData(d *schema.ResourceData, assigned_r
 func getAssignedRolesAttributes() []string {
 	attributes := [...]string{
 		"context_params", "created_at", "role_group_assignment_id", "role_group_id", "role_id",
-		"org_id", "name", "description", "internal",
+		"org_id", "env_id", "name", "description", "internal",
 	}
 	return attributes[:]
 }
@@ -2,6 +2,7 @@ package anypoint
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"time"
 
@@ -16,8 +17,11 @@ func resourceTeam() *schema.Resource {
 		ReadContext:   resourceTeamRead,
 		UpdateContext: resourceTeamUpdate,
 		DeleteContext: resourceTeamDelete,
+		CustomizeDiff: resourceTeamCustomizeDiff,
 		Description: `
 		Creates a ` + "`" + `team` + "`" + ` for your ` + "`" + `org` + "`" + `.
+
+Changing the ` + "`" + `parent_team_id` + "`" + ` moves the team (and its sub-teams) under the new parent. Moving a team under itself or under one of its own sub-teams is rejected at plan time.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -30,8 +34,9 @@ func resourceTeam() *schema.Resource {
 				ForceNew: true,
 			},
 			"parent_team_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the parent team, changing it moves the team in the hierarchy",
 			},
 			"team_name": {
 				Type:     schema.TypeString,
@@ -47,8 +52,9 @@ func resourceTeam() *schema.Resource {
 				Computed: true,
 			},
 			"ancestor_team_ids": {
-				Type:     schema.TypeList,
-				Optional: true,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The ids of the ancestors of the team, from the root team to the parent team",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -137,6 +143,10 @@ func resourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		})
 		return diags
 	}
+	// the parent is the last of the ancestors
+	if ancestors := res.GetAncestorTeamIds(); len(ancestors) > 0 {
+		d.Set("parent_team_id", ancestors[len(ancestors)-1])
+	}
 
 	return diags
 }
@@ -199,6 +209,48 @@ func resourceTeamUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	return resourceTeamRead(ctx, d, m)
 }
 
+/*
+ Checks that the new parent of the team isn't the team itself nor one of its descendants
+*/
+func resourceTeamCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("parent_team_id") {
+		return nil
+	}
+	if !d.NewValueKnown("parent_team_id") {
+		return d.SetNewComputed("ancestor_team_ids")
+	}
+
+	teamid := d.Id()
+	orgid := d.Get("org_id").(string)
+	parentid := d.Get("parent_team_id").(string)
+	if parentid == teamid {
+		return fmt.Errorf("team %s can't be its own parent", teamid)
+	}
+
+	pco := m.(ProviderConfOutput)
+	authctx := getTeamAuthCtx(ctx, &pco)
+	parent, httpr, err := pco.teamclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdGet(authctx, orgid, parentid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		return fmt.Errorf("unable to get parent team %s\n details: %s", parentid, details)
+	}
+	defer httpr.Body.Close()
+
+	for _, ancestor := range parent.GetAncestorTeamIds() {
+		if ancestor == teamid {
+			return fmt.Errorf("team %s can't be moved under %s which is one of its sub-teams", teamid, parentid)
+		}
+	}
+
+	return d.SetNew("ancestor_team_ids", append(parent.GetAncestorTeamIds(), parentid))
+}
+
 func resourceTeamDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -0,0 +1,326 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// the group mappings of a team are replaced as a whole, the changes of a same team are serialized
+var teamGroupMappingsLocks sync.Map
+
+func resourceTeamGroupMapping() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTeamGroupMappingCreate,
+		ReadContext:   resourceTeamGroupMappingRead,
+		UpdateContext: resourceTeamGroupMappingUpdate,
+		DeleteContext: resourceTeamGroupMappingDelete,
+		CustomizeDiff: resourceTeamGroupMappingCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTeamGroupMappingImport,
+		},
+		Description: `
+		Maps a single identity provider's group to a ` + "`" + `team` + "`" + ` for your ` + "`" + `org` + "`" + `.
+
+Unlike ` + "`" + `anypoint_team_group_mappings` + "`" + `, this resource only manages its own mapping and leaves the other mappings of the team untouched,
+so the groups of different identity providers can be mapped to a same team by separate configurations.
+The ` + "`" + `provider_id` + "`" + ` must refer to an identity provider of the org.
+
+The resource can be imported using the id ` + "`" + `ORG_ID/TEAM_ID/PROVIDER_ID/EXTERNAL_GROUP_NAME` + "`" + `.
+		`,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"provider_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the identity provider",
+			},
+			"external_group_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the group in the identity provider",
+			},
+			"membership_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The membership type of the group's users in the team, possible values: 'member' or 'maintainer'",
+				ValidateFunc: validation.StringInSlice(getTeamMembershipTypes(), false),
+			},
+		},
+	}
+}
+
+func resourceTeamGroupMappingCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+	if !d.NewValueKnown("org_id") || !d.NewValueKnown("provider_id") {
+		return nil
+	}
+	pco := m.(ProviderConfOutput)
+	return validateIdentityProviders(ctx, &pco, d.Get("org_id").(string), []string{d.Get("provider_id").(string)})
+}
+
+func resourceTeamGroupMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	teamid := d.Get("team_id").(string)
+	providerid := d.Get("provider_id").(string)
+	groupname := d.Get("external_group_name").(string)
+
+	errDiags := updateTeamGroupMappings(ctx, &pco, orgid, teamid, func(mappings []map[string]interface{}) ([]map[string]interface{}, error) {
+		if findTeamGroupMapping(mappings, providerid, groupname) >= 0 {
+			return nil, fmt.Errorf("the group %s of identity provider %s is already mapped to team %s, import it instead", groupname, providerid, teamid)
+		}
+		mapping := map[string]interface{}{
+			"membership_type":     d.Get("membership_type").(string),
+			"external_group_name": groupname,
+			"provider_id":         providerid,
+		}
+		return append(mappings, mapping), nil
+	})
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	d.SetId(composeTeamGroupMappingId(orgid, teamid, providerid, groupname))
+
+	return resourceTeamGroupMappingRead(ctx, d, m)
+}
+
+func resourceTeamGroupMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid, teamid, providerid, groupname := decomposeTeamGroupMappingId(d.Id())
+
+	mappings, errDiags := getTeamLiveGroupMappings(ctx, &pco, orgid, teamid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	i := findTeamGroupMapping(mappings, providerid, groupname)
+	if i < 0 {
+		// the group is no longer mapped to the team
+		d.SetId("")
+		return diags
+	}
+
+	attributes := map[string]interface{}{
+		"org_id":              orgid,
+		"team_id":             teamid,
+		"provider_id":         providerid,
+		"external_group_name": groupname,
+		"membership_type":     mappings[i]["membership_type"],
+	}
+	for attr, val := range attributes {
+		if err := d.Set(attr, val); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to set team " + teamid + " group mapping " + groupname,
+				Detail:   fmt.Sprintf("unable to set team group mapping attribute %s\n details: %s", attr, err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceTeamGroupMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid, teamid, providerid, groupname := decomposeTeamGroupMappingId(d.Id())
+
+	if d.HasChange("membership_type") {
+		errDiags := updateTeamGroupMappings(ctx, &pco, orgid, teamid, func(mappings []map[string]interface{}) ([]map[string]interface{}, error) {
+			i := findTeamGroupMapping(mappings, providerid, groupname)
+			if i < 0 {
+				return nil, fmt.Errorf("the group %s of identity provider %s is no longer mapped to team %s", groupname, providerid, teamid)
+			}
+			mappings[i]["membership_type"] = d.Get("membership_type").(string)
+			return mappings, nil
+		})
+		if errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+	}
+
+	return resourceTeamGroupMappingRead(ctx, d, m)
+}
+
+func resourceTeamGroupMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid, teamid, providerid, groupname := decomposeTeamGroupMappingId(d.Id())
+
+	errDiags := updateTeamGroupMappings(ctx, &pco, orgid, teamid, func(mappings []map[string]interface{}) ([]map[string]interface{}, error) {
+		i := findTeamGroupMapping(mappings, providerid, groupname)
+		if i < 0 {
+			return nil, nil
+		}
+		return append(mappings[:i], mappings[i+1:]...), nil
+	})
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTeamGroupMappingImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	split := strings.SplitN(d.Id(), "/", 4)
+	if len(split) != 4 {
+		return nil, fmt.Errorf("unexpected id %s, expected ORG_ID/TEAM_ID/PROVIDER_ID/EXTERNAL_GROUP_NAME", d.Id())
+	}
+	for _, part := range split {
+		if part == "" {
+			return nil, fmt.Errorf("unexpected id %s, expected ORG_ID/TEAM_ID/PROVIDER_ID/EXTERNAL_GROUP_NAME", d.Id())
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+/*
+ Returns the live group mappings of the given team as put body items
+*/
+func getTeamLiveGroupMappings(ctx context.Context, pco *ProviderConfOutput, orgid string, teamid string) ([]map[string]interface{}, diag.Diagnostics) {
+	authctx := getTeamGroupMappingsAuthCtx(ctx, pco)
+	req := pco.teamgroupmappingsclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdGroupmappingsGet(authctx, orgid, teamid)
+	data, _, diags := fetchTeamGroupMappingsPages(req, 0, defaultPageSize, true)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	mappings := make([]map[string]interface{}, len(data))
+	for i, mapping := range data {
+		mappings[i] = flattenTeamGroupMappingData(&mapping)
+	}
+	return mappings, diags
+}
+
+/*
+ Loads the live group mappings of the given team, applies the given change to them and replaces the mappings of the team by the result.
+ The change returns nil mappings when there is nothing to replace.
+ The changes of a same team are serialized so that concurrent resources don't override each other's mappings
+*/
+func updateTeamGroupMappings(ctx context.Context, pco *ProviderConfOutput, orgid string, teamid string, change func([]map[string]interface{}) ([]map[string]interface{}, error)) diag.Diagnostics {
+	var diags diag.Diagnostics
+	lock, _ := teamGroupMappingsLocks.LoadOrStore(orgid+"/"+teamid, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	live, errDiags := getTeamLiveGroupMappings(ctx, pco, orgid, teamid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+	mappings, err := change(live)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to update team " + teamid + " groupmappings",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if mappings == nil {
+		return diags
+	}
+
+	body := make([]map[string]interface{}, len(mappings))
+	for i, mapping := range mappings {
+		body[i] = newTeamGroupMappingPutItem(mapping)
+	}
+	authctx := getTeamGroupMappingsAuthCtx(ctx, pco)
+	httpr, err := pco.teamgroupmappingsclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdGroupmappingsPut(authctx, orgid, teamid).RequestBody(body).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to update team " + teamid + " groupmappings",
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+
+	return diags
+}
+
+/*
+ Returns the index of the mapping of the given identity provider group, -1 if the group is not mapped
+*/
+func findTeamGroupMapping(mappings []map[string]interface{}, providerid string, groupname string) int {
+	for i, mapping := range mappings {
+		if mapping["provider_id"] == providerid && mapping["external_group_name"] == groupname {
+			return i
+		}
+	}
+	return -1
+}
+
+/*
+ Returns the item of the group mappings put body for the given mapping, provider_id is only sent when set
+*/
+func newTeamGroupMappingPutItem(mapping map[string]interface{}) map[string]interface{} {
+	item := make(map[string]interface{})
+	item["membership_type"] = mapping["membership_type"]
+	item["external_group_name"] = mapping["external_group_name"]
+	if providerid, ok := mapping["provider_id"].(string); ok && providerid != "" {
+		item["provider_id"] = providerid
+	}
+	return item
+}
+
+/*
+ Returns the id of a team group mapping resource: ORG_ID/TEAM_ID/PROVIDER_ID/EXTERNAL_GROUP_NAME
+*/
+func composeTeamGroupMappingId(orgid string, teamid string, providerid string, groupname string) string {
+	return orgid + "/" + teamid + "/" + providerid + "/" + groupname
+}
+
+/*
+ Returns the org id, team id, provider id and external group name of the given team group mapping resource id.
+ The group name is the remainder of the id as it may contain slashes
+*/
+func decomposeTeamGroupMappingId(id string) (string, string, string, string) {
+	split := strings.SplitN(id, "/", 4)
+	parts := make([]string, 4)
+	copy(parts, split)
+	return parts[0], parts[1], parts[2], parts[3]
+}
@@ -16,10 +16,12 @@ func resourceTeamGroupMappings() *schema.Resource {
 		ReadContext:   resourceTeamGroupMappingsRead,
 		DeleteContext: resourceTeamGroupMappingsDelete,
 		UpdateContext: resourceTeamGroupMappingsUpdate,
+		CustomizeDiff: resourceTeamGroupMappingsCustomizeDiff,
 		Description: `
 		Maps identity providers' groups to a team.
 		You can map users in a federated organization’s group to a team or role. Your Anypoint Platform organization must use an external identity provider, such as PingFederate.
 		After you have mapped them, users in an organization can log in to Anypoint Platform using the same organizational credentials and access permissions that an organization maintains using SAML, OpenID Connect (OIDC), or LDAP.
+		This resource replaces all the group mappings of the team, use ` + "`" + `anypoint_team_group_mapping` + "`" + ` to manage the mappings of a team separately.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -67,6 +69,21 @@ func resourceTeamGroupMappings() *schema.Resource {
 	}
 }
 
+func resourceTeamGroupMappingsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("groupmappings") || !d.NewValueKnown("org_id") || !d.NewValueKnown("groupmappings") {
+		return nil
+	}
+	providerids := make([]string, 0)
+	for _, item := range d.Get("groupmappings").([]interface{}) {
+		content, _ := item.(map[string]interface{})
+		if providerid, _ := content["provider_id"].(string); providerid != "" {
+			providerids = append(providerids, providerid)
+		}
+	}
+	pco := m.(ProviderConfOutput)
+	return validateIdentityProviders(ctx, &pco, d.Get("org_id").(string), providerids)
+}
+
 func resourceTeamGroupMappingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -232,11 +249,7 @@ func newTeamGroupMappingsPutBody(d *schema.ResourceData) []map[string]interface{
 	body := make([]map[string]interface{}, len(teamgroupmappings))
 
 	for i, teamgroupmapping := range teamgroupmappings {
-		content := teamgroupmapping.(map[string]interface{})
-		item := make(map[string]interface{})
-		item["membership_type"] = content["membership_type"]
-		item["external_group_name"] = content["external_group_name"]
-		body[i] = item
+		body[i] = newTeamGroupMappingPutItem(teamgroupmapping.(map[string]interface{}))
 	}
 
 	return body
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	team_members "github.com/mulesoft-consulting/anypoint-client-go/team_members"
 )
 
@@ -16,6 +17,7 @@ func resourceTeamMember() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTeamMemberCreate,
 		ReadContext:   resourceTeamMemberRead,
+		UpdateContext: resourceTeamMemberUpdate,
 		DeleteContext: resourceTeamMemberDelete,
 		Description: `
 		Assignes a ` + "`" + `user` + "`" + ` to a ` + "`" + `team` + "`" + ` for your ` + "`" + `org` + "`" + `.
@@ -42,10 +44,11 @@ func resourceTeamMember() *schema.Resource {
 				ForceNew: true,
 			},
 			"membership_type": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "member",
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "member",
+				Description:  "The membership type of the user in the team, possible values: 'member' or 'maintainer'. Changing it updates the membership in place",
+				ValidateFunc: validation.StringInSlice(getTeamMembershipTypes(), false),
 			},
 			"identity_type": {
 				Type:     schema.TypeString,
@@ -56,8 +59,9 @@ func resourceTeamMember() *schema.Resource {
 				Computed: true,
 			},
 			"is_assigned_via_external_groups": {
-				Type:     schema.TypeBool,
-				Computed: true,
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user is a member of the team through external group mappings, in which case the membership type is managed by the identity provider",
 			},
 			"created_at": {
 				Type:     schema.TypeString,
@@ -116,9 +120,10 @@ func resourceTeamMemberRead(ctx context.Context, d *schema.ResourceData, m inter
 	split := strings.Split(id, "_")
 	orgid := split[0]
 	teamid := split[1]
+	userid := split[2]
 	authctx := getTeamMembersAuthCtx(ctx, &pco)
 	//request members
-	res, httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersGet(authctx, orgid, teamid).Execute()
+	res, httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersGet(authctx, orgid, teamid).MemberIds([]string{userid}).Execute()
 
 	if err != nil {
 		var details string
@@ -137,8 +142,20 @@ func resourceTeamMemberRead(ctx context.Context, d *schema.ResourceData, m inter
 	}
 	defer httpr.Body.Close()
 
-	item := res.GetData()[0]
-	teammember := flattenTeamMemberData(&item)
+	var item *team_members.TeamMember
+	for _, member := range res.GetData() {
+		if member.GetId() == userid {
+			member := member
+			item = &member
+			break
+		}
+	}
+	if item == nil {
+		// the user is no longer a member of the team
+		d.SetId("")
+		return diags
+	}
+	teammember := flattenTeamMemberData(item)
 
 	if err := setTeamMemberAttributesToResourceData(d, teammember); err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -148,10 +165,61 @@ func resourceTeamMemberRead(ctx context.Context, d *schema.ResourceData, m inter
 		})
 		return diags
 	}
+	// the membership type of members assigned via external groups is driven by the identity provider
+	if !item.GetIsAssignedViaExternalGroups() {
+		d.Set("membership_type", item.GetMembershipType())
+	}
+	d.Set("org_id", orgid)
+	d.Set("team_id", teamid)
+	d.Set("user_id", userid)
 
 	return diags
 }
 
+func resourceTeamMemberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	id := d.Id()
+	split := strings.Split(id, "_")
+	orgid := split[0]
+	teamid := split[1]
+	userid := split[2]
+	authctx := getTeamMembersAuthCtx(ctx, &pco)
+
+	if d.HasChange("membership_type") {
+		if d.Get("is_assigned_via_external_groups").(bool) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update team " + teamid + " member " + userid,
+				Detail:   "The user is a member of the team through external group mappings, its membership type is managed by the identity provider",
+			})
+			return diags
+		}
+		body := newTeamMemberPutBody(d)
+		httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersUserIdPut(authctx, orgid, teamid, userid).TeamMemberPutBody(*body).Execute()
+		if err != nil {
+			var details string
+			if httpr != nil {
+				b, _ := ioutil.ReadAll(httpr.Body)
+				details = string(b)
+			} else {
+				details = err.Error()
+			}
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update team " + teamid + " member " + userid,
+				Detail:   details,
+			})
+			return diags
+		}
+		defer httpr.Body.Close()
+		d.Set("last_updated", time.Now().Format(time.RFC850))
+	}
+
+	return resourceTeamMemberRead(ctx, d, m)
+}
+
 func resourceTeamMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -0,0 +1,353 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	team_members "github.com/mulesoft-consulting/anypoint-client-go/team_members"
+)
+
+// maximum number of membership changes sent at the same time when applying the team members diff
+const teamMembersBatchSize = 10
+
+func resourceTeamMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTeamMembersCreate,
+		ReadContext:   resourceTeamMembersRead,
+		UpdateContext: resourceTeamMembersUpdate,
+		DeleteContext: resourceTeamMembersDelete,
+		CustomizeDiff: resourceTeamMembersCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTeamMembersImport,
+		},
+		Description: `
+		Manages the complete list of ` + "`" + `users` + "`" + ` of a ` + "`" + `team` + "`" + ` for your ` + "`" + `org` + "`" + `.
+
+This resource is authoritative: members of the team that are not listed are removed from it, members whose membership type differs are updated.
+Members assigned to the team via external groups are ignored and cannot be listed.
+Don't use this resource together with ` + "`" + `anypoint_team_member` + "`" + ` on the same team.
+
+The resource can be imported using the id ` + "`" + `ORG_ID/TEAM_ID` + "`" + `.
+		`,
+		Schema: map[string]*schema.Schema{
+			"last_updated": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"org_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The users of the team",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The id of the user",
+						},
+						"membership_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "member",
+							Description:  "The membership type of the user in the team, possible values: 'member' or 'maintainer'",
+							ValidateFunc: validation.StringInSlice(getTeamMembershipTypes(), false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTeamMembersCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	seen := make(map[string]bool)
+	for _, item := range d.Get("members").(*schema.Set).List() {
+		userid := item.(map[string]interface{})["user_id"].(string)
+		if userid == "" {
+			continue
+		}
+		if seen[userid] {
+			return fmt.Errorf("user %s is listed more than once in members", userid)
+		}
+		seen[userid] = true
+	}
+	if !d.HasChange("members") || !d.NewValueKnown("org_id") || !d.NewValueKnown("team_id") {
+		return nil
+	}
+	// the external group members are ignored by the resource, listing them would never converge
+	pco := m.(ProviderConfOutput)
+	_, external, diags := getTeamMembersByAssignment(ctx, &pco, d.Get("org_id").(string), d.Get("team_id").(string))
+	if diags.HasError() {
+		return fmt.Errorf("unable to check the members of team %s: %s", d.Get("team_id").(string), diags[0].Detail)
+	}
+	for userid := range seen {
+		if external[userid] {
+			return fmt.Errorf("user %s is a member of team %s via external groups only, it cannot be listed in members", userid, d.Get("team_id").(string))
+		}
+	}
+	return nil
+}
+
+func resourceTeamMembersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgid := d.Get("org_id").(string)
+	teamid := d.Get("team_id").(string)
+
+	if errDiags := applyTeamMembers(ctx, d, m, orgid, teamid); errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	d.SetId(orgid + "_" + teamid + "_members")
+	d.Set("last_updated", time.Now().Format(time.RFC850))
+
+	return resourceTeamMembersRead(ctx, d, m)
+}
+
+func resourceTeamMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	id := d.Id()
+	split := strings.Split(id, "_")
+	orgid := split[0]
+	teamid := split[1]
+
+	live, errDiags := getTeamDirectMembers(ctx, &pco, orgid, teamid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	members := make([]interface{}, 0, len(live))
+	for userid, membershiptype := range live {
+		item := make(map[string]interface{})
+		item["user_id"] = userid
+		item["membership_type"] = membershiptype
+		members = append(members, item)
+	}
+	if err := d.Set("members", members); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set members for team " + teamid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.Set("org_id", orgid)
+	d.Set("team_id", teamid)
+
+	return diags
+}
+
+func resourceTeamMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	id := d.Id()
+	split := strings.Split(id, "_")
+	orgid := split[0]
+	teamid := split[1]
+
+	if d.HasChange("members") {
+		if errDiags := applyTeamMembers(ctx, d, m, orgid, teamid); errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+		d.Set("last_updated", time.Now().Format(time.RFC850))
+	}
+
+	return resourceTeamMembersRead(ctx, d, m)
+}
+
+func resourceTeamMembersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	id := d.Id()
+	split := strings.Split(id, "_")
+	orgid := split[0]
+	teamid := split[1]
+
+	live, errDiags := getTeamDirectMembers(ctx, &pco, orgid, teamid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	//only removes the members managed by this resource that are still in the team
+	removes := make([]string, 0)
+	for _, item := range d.Get("members").(*schema.Set).List() {
+		userid := item.(map[string]interface{})["user_id"].(string)
+		if _, ok := live[userid]; ok {
+			removes = append(removes, userid)
+		}
+	}
+	if errDiags := execTeamMembersChanges(ctx, &pco, orgid, teamid, nil, removes); errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTeamMembersImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	split := strings.Split(d.Id(), "/")
+	if len(split) != 2 || split[0] == "" || split[1] == "" {
+		return nil, fmt.Errorf("unexpected id %s, expected ORG_ID/TEAM_ID", d.Id())
+	}
+	d.SetId(split[0] + "_" + split[1] + "_members")
+	return []*schema.ResourceData{d}, nil
+}
+
+/*
+ Compares the members of the resource with the live members of the team and applies the differences:
+ missing members are added, members with a different membership type are updated and the others are removed
+*/
+func applyTeamMembers(ctx context.Context, d *schema.ResourceData, m interface{}, orgid string, teamid string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+
+	live, errDiags := getTeamDirectMembers(ctx, &pco, orgid, teamid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	desired := make(map[string]string)
+	for _, item := range d.Get("members").(*schema.Set).List() {
+		content := item.(map[string]interface{})
+		desired[content["user_id"].(string)] = content["membership_type"].(string)
+	}
+
+	puts := make(map[string]string)
+	for userid, membershiptype := range desired {
+		if current, ok := live[userid]; !ok || current != membershiptype {
+			puts[userid] = membershiptype
+		}
+	}
+	removes := make([]string, 0)
+	for userid := range live {
+		if _, ok := desired[userid]; !ok {
+			removes = append(removes, userid)
+		}
+	}
+
+	return execTeamMembersChanges(ctx, &pco, orgid, teamid, puts, removes)
+}
+
+/*
+ Returns the membership type of each member of the given team indexed by user id.
+ Members assigned via external groups are ignored
+*/
+func getTeamDirectMembers(ctx context.Context, pco *ProviderConfOutput, orgid string, teamid string) (map[string]string, diag.Diagnostics) {
+	members, _, diags := getTeamMembersByAssignment(ctx, pco, orgid, teamid)
+	return members, diags
+}
+
+/*
+ Returns the membership type of each direct member of the given team indexed by user id,
+ and the ids of the members assigned via external groups
+*/
+func getTeamMembersByAssignment(ctx context.Context, pco *ProviderConfOutput, orgid string, teamid string) (map[string]string, map[string]bool, diag.Diagnostics) {
+	authctx := getTeamMembersAuthCtx(ctx, pco)
+	req := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersGet(authctx, orgid, teamid)
+	data, _, diags := fetchTeamMembersPages(req, 0, defaultPageSize, true)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	members := make(map[string]string)
+	external := make(map[string]bool)
+	for _, member := range data {
+		if member.GetIsAssignedViaExternalGroups() {
+			external[member.GetId()] = true
+			continue
+		}
+		members[member.GetId()] = member.GetMembershipType()
+	}
+	return members, external, diags
+}
+
+/*
+ Sets the membership type of the given users (puts) and removes the given users from the team (removes).
+ The changes are sent in batches of concurrent requests, a failing batch stops the process
+*/
+func execTeamMembersChanges(ctx context.Context, pco *ProviderConfOutput, orgid string, teamid string, puts map[string]string, removes []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	authctx := getTeamMembersAuthCtx(ctx, pco)
+
+	changes := make([]func() diag.Diagnostics, 0, len(puts)+len(removes))
+	putids := make([]string, 0, len(puts))
+	for userid := range puts {
+		putids = append(putids, userid)
+	}
+	sort.Strings(putids)
+	for _, userid := range putids {
+		userid := userid
+		body := team_members.NewTeamMemberPutBodyWithDefaults()
+		body.SetMembershipType(puts[userid])
+		changes = append(changes, func() diag.Diagnostics {
+			httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersUserIdPut(authctx, orgid, teamid, userid).TeamMemberPutBody(*body).Execute()
+			return changeRequestDiags(httpr, err, "Unable to set team "+teamid+" member "+userid)
+		})
+	}
+	sort.Strings(removes)
+	for _, userid := range removes {
+		userid := userid
+		changes = append(changes, func() diag.Diagnostics {
+			httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersUserIdDelete(authctx, orgid, teamid, userid).Execute()
+			return changeRequestDiags(httpr, err, "Unable to remove team "+teamid+" member "+userid)
+		})
+	}
+
+	for start := 0; start < len(changes); start += teamMembersBatchSize {
+		end := start + teamMembersBatchSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		for _, change := range changes[start:end] {
+			wg.Add(1)
+			go func(change func() diag.Diagnostics) {
+				defer wg.Done()
+				if errDiags := change(); errDiags.HasError() {
+					mu.Lock()
+					diags = append(diags, errDiags...)
+					mu.Unlock()
+				}
+			}(change)
+		}
+		wg.Wait()
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func getTeamMembershipTypes() []string {
+	types := [...]string{
+		"member", "maintainer",
+	}
+	return types[:]
+}
@@ -0,0 +1,246 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceTeamRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTeamRoleCreate,
+		ReadContext:   resourceTeamRoleRead,
+		DeleteContext: resourceTeamRoleDelete,
+		CustomizeDiff: resourceTeamRoleCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTeamRoleImport,
+		},
+		Description: `
+		Assigns a single ` + "`" + `role` + "`" + ` to a ` + "`" + `team` + "`" + ` for your ` + "`" + `org` + "`" + `.
+
+Unlike ` + "`" + `anypoint_team_roles` + "`" + `, this resource only manages its own role assignment and leaves the other roles of the team untouched.
+Don't use this resource together with ` + "`" + `anypoint_team_roles` + "`" + ` on the same team: ` + "`" + `anypoint_team_roles` + "`" + ` is authoritative and revokes the roles of the team it doesn't list, including the ones assigned by this resource.
+The role applies to the business group ` + "`" + `role_org_id` + "`" + `, which defaults to the business group ` + "`" + `org_id` + "`" + ` of the team, and to the environment ` + "`" + `env_id` + "`" + ` for environment scoped roles.
+
+The resource can be imported using the id ` + "`" + `ORG_ID/TEAM_ID/ROLE_ID` + "`" + `, ` + "`" + `ORG_ID/TEAM_ID/ROLE_ID/ROLE_ORG_ID` + "`" + ` or ` + "`" + `ORG_ID/TEAM_ID/ROLE_ID/ROLE_ORG_ID/ENV_ID` + "`" + `.
+		`,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The business group of the team",
+			},
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role_org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The business group to which the role applies, defaults to the org_id of the team",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The environment to which the role applies, only for environment scoped roles",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"context_params": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The context of the role assignment as sent to the platform",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceTeamRoleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+	for _, attr := range []string{"org_id", "role_org_id", "role_id", "env_id"} {
+		if !d.NewValueKnown(attr) {
+			return nil
+		}
+	}
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	roleorgid := d.Get("role_org_id").(string)
+	if roleorgid == "" {
+		// the role applies to the business group of the team by default
+		roleorgid = orgid
+		if err := d.SetNew("role_org_id", roleorgid); err != nil {
+			return err
+		}
+	}
+	assignment := map[string]interface{}{
+		"role_id": d.Get("role_id").(string),
+		"org_id":  roleorgid,
+		"env_id":  d.Get("env_id").(string),
+	}
+	return validateRoleAssignments(ctx, &pco, orgid, []interface{}{assignment})
+}
+
+func resourceTeamRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	teamid := d.Get("team_id").(string)
+	roleid := d.Get("role_id").(string)
+	envid := d.Get("env_id").(string)
+	assignment := newTeamRoleAssignment(d)
+
+	if errDiags := postTeamRoles(ctx, &pco, orgid, teamid, []interface{}{assignment}); errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	d.SetId(composeTeamRoleId(orgid, teamid, roleid, assignment["org_id"].(string), envid))
+
+	return resourceTeamRoleRead(ctx, d, m)
+}
+
+func resourceTeamRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid, teamid, roleid, roleorgid, envid := decomposeTeamRoleId(d.Id())
+	authctx := getTeamRolesAuthCtx(ctx, &pco)
+
+	//request the assignments of the role
+	req := pco.teamrolesclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdRolesGet(authctx, orgid, teamid).RoleId(roleid)
+	data, _, errDiags := fetchTeamRolesPages(req, 0, defaultPageSize, true)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	//look for the assignment having the same context
+	key := teamRoleKey(map[string]interface{}{"role_id": roleid, "org_id": roleorgid, "env_id": envid})
+	var role map[string]interface{}
+	for _, item := range flattenTeamRolesData(&data) {
+		if teamRoleKey(item) == key {
+			role = item.(map[string]interface{})
+			break
+		}
+	}
+	if role == nil {
+		// the role is no longer assigned to the team
+		d.SetId("")
+		return diags
+	}
+
+	attributes := map[string]interface{}{
+		"org_id":         orgid,
+		"team_id":        teamid,
+		"role_id":        roleid,
+		"role_org_id":    roleorgid,
+		"env_id":         envid,
+		"name":           role["name"],
+		"context_params": role["context_params"],
+	}
+	for attr, val := range attributes {
+		if err := d.Set(attr, val); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to set team " + teamid + " role " + roleid,
+				Detail:   fmt.Sprintf("unable to set team role attribute %s\n details: %s", attr, err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceTeamRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid, teamid, _, _, _ := decomposeTeamRoleId(d.Id())
+
+	if errDiags := deleteTeamRoles(ctx, &pco, orgid, teamid, []interface{}{newTeamRoleAssignment(d)}); errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTeamRoleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	split := strings.Split(d.Id(), "/")
+	if len(split) < 3 || len(split) > 5 {
+		return nil, fmt.Errorf("unexpected id %s, expected ORG_ID/TEAM_ID/ROLE_ID, ORG_ID/TEAM_ID/ROLE_ID/ROLE_ORG_ID or ORG_ID/TEAM_ID/ROLE_ID/ROLE_ORG_ID/ENV_ID", d.Id())
+	}
+	for _, part := range split {
+		if part == "" {
+			return nil, fmt.Errorf("unexpected id %s, expected ORG_ID/TEAM_ID/ROLE_ID, ORG_ID/TEAM_ID/ROLE_ID/ROLE_ORG_ID or ORG_ID/TEAM_ID/ROLE_ID/ROLE_ORG_ID/ENV_ID", d.Id())
+		}
+	}
+	orgid, teamid, roleid, roleorgid, envid := decomposeTeamRoleId(d.Id())
+	if roleorgid == "" {
+		roleorgid = orgid
+	}
+	d.SetId(composeTeamRoleId(orgid, teamid, roleid, roleorgid, envid))
+	return []*schema.ResourceData{d}, nil
+}
+
+/*
+ Returns the role assignment (role_id, org_id and env_id) managed by the resource,
+ the org_id of the assignment is the role_org_id, or the org_id of the team if not set
+*/
+func newTeamRoleAssignment(d *schema.ResourceData) map[string]interface{} {
+	roleorgid := d.Get("role_org_id").(string)
+	if roleorgid == "" {
+		roleorgid = d.Get("org_id").(string)
+	}
+	return map[string]interface{}{
+		"role_id": d.Get("role_id").(string),
+		"org_id":  roleorgid,
+		"env_id":  d.Get("env_id").(string),
+	}
+}
+
+/*
+ Returns the id of a team role resource: ORG_ID/TEAM_ID/ROLE_ID/ROLE_ORG_ID or ORG_ID/TEAM_ID/ROLE_ID/ROLE_ORG_ID/ENV_ID for environment scoped roles
+*/
+func composeTeamRoleId(orgid string, teamid string, roleid string, roleorgid string, envid string) string {
+	id := orgid + "/" + teamid + "/" + roleid + "/" + roleorgid
+	if envid != "" {
+		id += "/" + envid
+	}
+	return id
+}
+
+/*
+ Returns the org id, team id, role id, role org id and env id (possibly empty) of the given team role resource id
+*/
+func decomposeTeamRoleId(id string) (string, string, string, string, string) {
+	split := strings.Split(id, "/")
+	parts := make([]string, 5)
+	copy(parts, split)
+	return parts[0], parts[1], parts[2], parts[3], parts[4]
+}
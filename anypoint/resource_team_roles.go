@@ -2,8 +2,10 @@ package anypoint
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,13 +16,20 @@ func resourceTeamRoles() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTeamRolesCreate,
 		ReadContext:   resourceTeamRolesRead,
+		UpdateContext: resourceTeamRolesUpdate,
 		DeleteContext: resourceTeamRolesDelete,
+		CustomizeDiff: resourceTeamRolesCustomizeDiff,
 		Description: `
 		Attributes ` + "`" + `roles` + "`" + ` to your selected ` + "`" + `team` + "`" + ` for your ` + "`" + `org` + "`" + `.
 
 Depending on the ` + "`" + `role` + "`" + `, some roles are environment scoped others are business group scoped :
-* For environment scoped roles, the org id and environment id needs to be specified.
-* For business group scoped roles, only the org id is needed.
+* For environment scoped roles, the ` + "`" + `org_id` + "`" + ` and ` + "`" + `env_id` + "`" + ` needs to be specified.
+* For business group scoped roles, only the ` + "`" + `org_id` + "`" + ` is needed.
+
+The existence of the roles and the business group of the environments are checked at plan time.
+The roles catalogue doesn't tell which roles are environment scoped, so a role given the wrong scope is only rejected by the platform when applying.
+
+Changing the roles only assigns the added roles and revokes the removed ones, the unchanged roles are never revoked.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -39,9 +48,9 @@ Depending on the ` + "`" + `role` + "`" + `, some roles are environment scoped o
 				ForceNew: true,
 			},
 			"roles": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Required: true,
-				ForceNew: true,
+				Set:      hashTeamRole,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -51,12 +60,27 @@ Depending on the ` + "`" + `role` + "`" + `, some roles are environment scoped o
 						"role_id": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
+						},
+						"org_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The business group to which the role applies, required unless the deprecated context_params is used",
+						},
+						"env_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The environment to which the role applies, only for environment scoped roles",
 						},
 						"context_params": {
-							Type:     schema.TypeMap,
-							Required: true,
-							ForceNew: true,
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Computed:    true,
+							Description: "The context of the role assignment as sent to the platform",
+							Deprecated:  "use org_id and env_id instead, the org and envId context params are mapped onto them",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
 						},
 					},
 				},
@@ -70,33 +94,38 @@ Depending on the ` + "`" + `role` + "`" + `, some roles are environment scoped o
 	}
 }
 
+func resourceTeamRolesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("roles") || !d.NewValueKnown("roles") {
+		return nil
+	}
+	roles := d.Get("roles").(*schema.Set).List()
+	for _, role := range roles {
+		content := role.(map[string]interface{})
+		if orgid, _ := getRoleAssignmentScope(content); orgid == "" {
+			return fmt.Errorf("role %s requires an org_id", content["role_id"])
+		}
+	}
+	pco := m.(ProviderConfOutput)
+	return validateRoleAssignments(ctx, &pco, d.Get("org_id").(string), roles)
+}
+
 func resourceTeamRolesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 	pco := m.(ProviderConfOutput)
 	orgid := d.Get("org_id").(string)
 	teamid := d.Get("team_id").(string)
-	authctx := getTeamRolesAuthCtx(ctx, &pco)
-	body := newTeamRolesPostBody(d)
 
-	//request user creation
-	httpr, err := pco.teamrolesclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdRolesPost(authctx, orgid, teamid).RequestBody(body).Execute()
-	if err != nil {
-		var details string
-		if httpr != nil {
-			b, _ := ioutil.ReadAll(httpr.Body)
-			details = string(b)
-		} else {
-			details = err.Error()
-		}
-		diags := append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create team roles ",
-			Detail:   details,
-		})
+	live, errDiags := getTeamLiveRoles(ctx, &pco, orgid, teamid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+	adds, _ := diffTeamRoles(live, d.Get("roles").(*schema.Set).List())
+	if errDiags := postTeamRoles(ctx, &pco, orgid, teamid, adds); errDiags.HasError() {
+		diags = append(diags, errDiags...)
 		return diags
 	}
-	defer httpr.Body.Close()
 
 	d.SetId(orgid + "_" + teamid + "_roles")
 
@@ -115,26 +144,15 @@ func resourceTeamRolesRead(ctx context.Context, d *schema.ResourceData, m interf
 	teamid := split[1]
 	authctx := getTeamRolesAuthCtx(ctx, &pco)
 	//request roles
-	res, httpr, err := pco.teamrolesclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdRolesGet(authctx, orgid, teamid).Limit(500).Execute()
-	if err != nil {
-		var details string
-		if httpr != nil {
-			b, _ := ioutil.ReadAll(httpr.Body)
-			details = string(b)
-		} else {
-			details = err.Error()
-		}
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to get team " + teamid + " roles",
-			Detail:   details,
-		})
+	req := pco.teamrolesclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdRolesGet(authctx, orgid, teamid)
+	data, total, errDiags := fetchTeamRolesPages(req, 0, defaultPageSize, true)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
 		return diags
 	}
-	defer httpr.Body.Close()
 
 	//process data
-	roles := flattenTeamRolesData(res.Data)
+	roles := flattenTeamRolesData(&data)
 	//save in data source schema
 	if err := d.Set("roles", roles); err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -145,7 +163,7 @@ func resourceTeamRolesRead(ctx context.Context, d *schema.ResourceData, m interf
 		return diags
 	}
 
-	if err := d.Set("total", res.GetTotal()); err != nil {
+	if err := d.Set("total", total); err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to set total number of team " + teamid + " roles",
@@ -157,6 +175,37 @@ func resourceTeamRolesRead(ctx context.Context, d *schema.ResourceData, m interf
 	return diags
 }
 
+func resourceTeamRolesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	id := d.Id()
+	split := strings.Split(id, "_")
+	orgid := split[0]
+	teamid := split[1]
+
+	if d.HasChange("roles") {
+		live, errDiags := getTeamLiveRoles(ctx, &pco, orgid, teamid)
+		if errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+		adds, removes := diffTeamRoles(live, d.Get("roles").(*schema.Set).List())
+		//adds first so that the team is never left without the roles that are kept or replaced
+		if errDiags := postTeamRoles(ctx, &pco, orgid, teamid, adds); errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+		if errDiags := deleteTeamRoles(ctx, &pco, orgid, teamid, removes); errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+		d.Set("last_updated", time.Now().Format(time.RFC850))
+	}
+
+	return resourceTeamRolesRead(ctx, d, m)
+}
+
 func resourceTeamRolesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -165,9 +214,103 @@ func resourceTeamRolesDelete(ctx context.Context, d *schema.ResourceData, m inte
 	split := strings.Split(id, "_")
 	orgid := split[0]
 	teamid := split[1]
-	authctx := getTeamRolesAuthCtx(ctx, &pco)
 
-	body := newTeamRolesDeleteBody(d)
+	if errDiags := deleteTeamRoles(ctx, &pco, orgid, teamid, d.Get("roles").(*schema.Set).List()); errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
+
+/*
+ Returns the roles currently assigned to the given team
+*/
+func getTeamLiveRoles(ctx context.Context, pco *ProviderConfOutput, orgid string, teamid string) ([]interface{}, diag.Diagnostics) {
+	authctx := getTeamRolesAuthCtx(ctx, pco)
+	req := pco.teamrolesclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdRolesGet(authctx, orgid, teamid)
+	data, _, diags := fetchTeamRolesPages(req, 0, defaultPageSize, true)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return flattenTeamRolesData(&data), diags
+}
+
+/*
+ Compares the live roles of a team with the desired ones (both as (role_id, org_id, env_id) items).
+ Returns the roles to assign and the roles to revoke
+*/
+func diffTeamRoles(live []interface{}, desired []interface{}) ([]interface{}, []interface{}) {
+	livekeys := make(map[string]bool)
+	for _, role := range live {
+		livekeys[teamRoleKey(role)] = true
+	}
+	desiredkeys := make(map[string]bool)
+	for _, role := range desired {
+		desiredkeys[teamRoleKey(role)] = true
+	}
+
+	adds := make([]interface{}, 0)
+	for _, role := range desired {
+		if !livekeys[teamRoleKey(role)] {
+			adds = append(adds, role)
+		}
+	}
+	removes := make([]interface{}, 0)
+	for _, role := range live {
+		if !desiredkeys[teamRoleKey(role)] {
+			removes = append(removes, role)
+		}
+	}
+	return adds, removes
+}
+
+/*
+ Assigns the given roles to the team
+*/
+func postTeamRoles(ctx context.Context, pco *ProviderConfOutput, orgid string, teamid string, roles []interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	body := newTeamRolesPostBody(roles)
+	if len(body) == 0 {
+		return diags
+	}
+	authctx := getTeamRolesAuthCtx(ctx, pco)
+
+	httpr, err := pco.teamrolesclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdRolesPost(authctx, orgid, teamid).RequestBody(body).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create team " + teamid + " roles",
+			Detail:   details + "\n" + getRoleScopeHint(),
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+
+	return diags
+}
+
+/*
+ Revokes the given roles of the team
+*/
+func deleteTeamRoles(ctx context.Context, pco *ProviderConfOutput, orgid string, teamid string, roles []interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	body := newTeamRolesDeleteBody(roles)
+	if len(body) == 0 {
+		return diags
+	}
+	authctx := getTeamRolesAuthCtx(ctx, pco)
 
 	httpr, err := pco.teamrolesclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdRolesDelete(authctx, orgid, teamid).RequestBody(body).Execute()
 	if err != nil {
@@ -186,16 +329,11 @@ func resourceTeamRolesDelete(ctx context.Context, d *schema.ResourceData, m inte
 		return diags
 	}
 	defer httpr.Body.Close()
-	// d.SetId("") is automatically called assuming delete returns no errors, but
-	// it is added here for explicitness.
-	d.SetId("")
 
 	return diags
 }
 
-func newTeamRolesPostBody(d *schema.ResourceData) []map[string]interface{} {
-	roles := d.Get("roles").([]interface{})
-
+func newTeamRolesPostBody(roles []interface{}) []map[string]interface{} {
 	if roles == nil || len(roles) <= 0 {
 		return make([]map[string]interface{}, 0)
 	}
@@ -206,16 +344,14 @@ func newTeamRolesPostBody(d *schema.ResourceData) []map[string]interface{} {
 		content := role.(map[string]interface{})
 		item := make(map[string]interface{})
 		item["role_id"] = content["role_id"]
-		item["context_params"] = content["context_params"]
+		item["context_params"] = newRoleContextParams(content)
 		body[i] = item
 	}
 
 	return body
 }
 
-func newTeamRolesDeleteBody(d *schema.ResourceData) []map[string]interface{} {
-	roles := d.Get("roles").([]interface{})
-
+func newTeamRolesDeleteBody(roles []interface{}) []map[string]interface{} {
 	if roles == nil || len(roles) <= 0 {
 		return make([]map[string]interface{}, 0)
 	}
@@ -229,13 +365,62 @@ func newTeamRolesDeleteBody(d *schema.ResourceData) []map[string]interface{} {
 		}
 		item := make(map[string]interface{})
 		item["role_id"] = content["role_id"]
-		item["context_params"] = content["context_params"]
+		item["context_params"] = newRoleContextParams(content)
 		body = append(body, item)
 	}
 
 	return body
 }
 
+/*
+ Returns the context params of a role assignment from its org_id and env_id
+*/
+func newRoleContextParams(role map[string]interface{}) map[string]interface{} {
+	params := make(map[string]interface{})
+	orgid, envid := getRoleAssignmentScope(role)
+	if orgid != "" {
+		params["org"] = orgid
+	}
+	if envid != "" {
+		params["envId"] = envid
+	}
+	return params
+}
+
+/*
+ Returns the org id and env id of a role assignment.
+ The org and envId of the deprecated context_params are used when org_id and env_id are not set
+*/
+func getRoleAssignmentScope(role map[string]interface{}) (string, string) {
+	orgid, _ := role["org_id"].(string)
+	envid, _ := role["env_id"].(string)
+	params, _ := role["context_params"].(map[string]interface{})
+	if orgid == "" {
+		orgid, _ = params["org"].(string)
+	}
+	if envid == "" {
+		envid, _ = params["envId"].(string)
+	}
+	return orgid, envid
+}
+
+/*
+ Returns a key identifying a team role assignment by its role id, org id and env id
+*/
+func teamRoleKey(role interface{}) string {
+	content := role.(map[string]interface{})
+	roleid, _ := content["role_id"].(string)
+	orgid, envid := getRoleAssignmentScope(content)
+	return roleid + "|" + orgid + "|" + envid
+}
+
+/*
+ Hashes the team roles using only their role id, org id and env id
+*/
+func hashTeamRole(role interface{}) int {
+	return schema.HashString(teamRoleKey(role))
+}
+
 /*
  * Returns authentication context (includes authorization header)
  */
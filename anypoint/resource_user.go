@@ -20,6 +20,9 @@ func resourceUser() *schema.Resource {
 		Creates a ` + "`" + `user` + "`" + ` for your org. 
 
 **N.B:** you can use a username only once even after it's deleted.
+
+The ` + "`" + `password` + "`" + ` is only sent when the user is created and is never stored in the state.
+To set a new password, update ` + "`" + `password` + "`" + ` and change ` + "`" + `password_version` + "`" + `.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -57,9 +60,19 @@ func resourceUser() *schema.Resource {
 				Sensitive: true,
 			},
 			"password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The user's initial password, only sent on creation or when password_version changes. It is never stored in the state",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					oldversion, newversion := d.GetChange("password_version")
+					return d.Id() != "" && oldversion == newversion
+				},
+			},
+			"password_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Any value, changing it sets the user's password to the current value of password",
 			},
 			"id": {
 				Type:     schema.TypeString,
@@ -212,6 +225,8 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		})
 		return diags
 	}
+	// the password is write-only, make sure it never lives in the state
+	d.Set("password", "")
 
 	return diags
 }
@@ -327,8 +342,8 @@ func newUserPutBody(d *schema.ResourceData) *user.UserPutBody {
 	if phone_number := d.Get("phone_number"); phone_number != nil {
 		body.SetPhoneNumber(d.Get("phone_number").(string))
 	}
-	if password := d.Get("password"); password != nil {
-		body.SetPassword(password.(string))
+	if d.HasChange("password_version") {
+		body.SetPassword(d.Get("password").(string))
 	}
 
 	return body
@@ -336,7 +351,7 @@ func newUserPutBody(d *schema.ResourceData) *user.UserPutBody {
 
 func getUserWatchAttributes() []string {
 	attributes := [...]string{
-		"first_name", "last_name", "properties", "email", "phone_number",
+		"first_name", "last_name", "properties", "email", "phone_number", "password_version",
 	}
 	return attributes[:]
 }
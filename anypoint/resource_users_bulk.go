@@ -0,0 +1,509 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	team_members "github.com/mulesoft-consulting/anypoint-client-go/team_members"
+	"github.com/mulesoft-consulting/anypoint-client-go/user"
+)
+
+// maximum number of users reconciled at the same time by the users bulk resource
+const usersBulkBatchSize = 10
+
+func resourceUsersBulk() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUsersBulkCreate,
+		ReadContext:   resourceUsersBulkRead,
+		UpdateContext: resourceUsersBulkUpdate,
+		DeleteContext: resourceUsersBulkDelete,
+		CustomizeDiff: resourceUsersBulkCustomizeDiff,
+		Description: `
+		Provisions a list of ` + "`" + `users` + "`" + ` in your ` + "`" + `org` + "`" + ` and their ` + "`" + `team` + "`" + ` memberships, typically loaded with ` + "`" + `csvdecode` + "`" + ` or ` + "`" + `jsondecode` + "`" + `.
+
+The listed users are reconciled against the org by username: missing users are created, users whose attributes differ are updated.
+Each user is added as a member of the listed teams and removed from the teams that are no longer listed for it, its other memberships are left untouched.
+A failing user doesn't fail the whole batch, the outcome of each user is reported in ` + "`" + `results` + "`" + ` and failures are raised as warnings. The failed users are retried on the next apply.
+
+The platform API used by the provider doesn't allow disabling users: users removed from the list are only deleted when ` + "`" + `delete_removed_users` + "`" + ` is set, otherwise they are left in the org and are no longer managed.
+
+The ` + "`" + `password` + "`" + ` of a user is only sent when the user is created.
+		`,
+		Schema: map[string]*schema.Schema{
+			"last_updated": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"org_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "The users to provision, usernames must be unique",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"first_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"last_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"phone_number": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The user's phone number, left untouched when empty",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The user's initial password, only sent when the user is created. It is never stored in the state",
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return d.Id() != "" && isUsersBulkManagedUser(d, d.Get(strings.TrimSuffix(k, "password")+"username").(string))
+							},
+						},
+						"teams": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The ids of the teams the user is a member of",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"delete_removed_users": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the users removed from the list, or all the users when the resource is destroyed. By default they are left in the org",
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The outcome of the last apply for each user",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "One of created, updated, unchanged, deleted, released or failed",
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The reason of the failure, for failed users",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceUsersBulkCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	seen := make(map[string]bool)
+	for _, item := range d.Get("users").([]interface{}) {
+		content, _ := item.(map[string]interface{})
+		username, _ := content["username"].(string)
+		if username == "" {
+			continue
+		}
+		if seen[username] {
+			return fmt.Errorf("user %s is listed more than once in users", username)
+		}
+		seen[username] = true
+	}
+	return nil
+}
+
+func resourceUsersBulkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgid := d.Get("org_id").(string)
+
+	d.SetId(orgid + "_users")
+
+	diags = append(diags, applyUsersBulk(ctx, d, m, orgid, make([]interface{}, 0))...)
+	if diags.HasError() {
+		return diags
+	}
+	d.Set("last_updated", time.Now().Format(time.RFC850))
+
+	return append(diags, resourceUsersBulkRead(ctx, d, m)...)
+}
+
+func resourceUsersBulkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+
+	live, errDiags := getUsersByUsername(ctx, &pco, orgid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	//refresh the managed users, the users missing in the org are dropped so that they are created again
+	users := make([]interface{}, 0)
+	for _, item := range d.Get("users").([]interface{}) {
+		content := item.(map[string]interface{})
+		usr, ok := live[content["username"].(string)]
+		if !ok {
+			continue
+		}
+		content["first_name"] = usr.GetFirstName()
+		content["last_name"] = usr.GetLastName()
+		content["email"] = usr.GetEmail()
+		// an empty phone number means it is not managed
+		if content["phone_number"].(string) != "" {
+			content["phone_number"] = usr.GetPhoneNumber()
+		}
+		// the password is write-only, make sure it never lives in the state
+		content["password"] = ""
+		users = append(users, content)
+	}
+	if err := d.Set("users", users); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set users of org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+func resourceUsersBulkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgid := d.Get("org_id").(string)
+
+	if d.HasChange("users") {
+		old, _ := d.GetChange("users")
+		diags = append(diags, applyUsersBulk(ctx, d, m, orgid, old.([]interface{}))...)
+		if diags.HasError() {
+			return diags
+		}
+		d.Set("last_updated", time.Now().Format(time.RFC850))
+	}
+
+	return append(diags, resourceUsersBulkRead(ctx, d, m)...)
+}
+
+func resourceUsersBulkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+
+	if d.Get("delete_removed_users").(bool) {
+		live, errDiags := getUsersByUsername(ctx, &pco, orgid)
+		if errDiags.HasError() {
+			diags = append(diags, errDiags...)
+			return diags
+		}
+		authctx := getUserAuthCtx(ctx, &pco)
+		for _, item := range d.Get("users").([]interface{}) {
+			username := item.(map[string]interface{})["username"].(string)
+			usr, ok := live[username]
+			if !ok {
+				continue
+			}
+			httpr, err := pco.userclient.DefaultApi.OrganizationsOrgIdUsersUserIdDelete(authctx, orgid, usr.GetId()).Execute()
+			if errDiags := changeRequestDiags(httpr, err, "Unable to delete user "+username); errDiags.HasError() {
+				diags = append(diags, errDiags...)
+				return diags
+			}
+		}
+	}
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
+
+/*
+ Reconciles the users of the resource with the users of the org, the given old users are the ones of the previous apply.
+ The users are processed concurrently, the failure of a user is reported as a warning and in the results
+*/
+func applyUsersBulk(ctx context.Context, d *schema.ResourceData, m interface{}, orgid string, old []interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+
+	live, errDiags := getUsersByUsername(ctx, &pco, orgid)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return diags
+	}
+
+	previous := make(map[string]map[string]interface{})
+	for _, item := range old {
+		content := item.(map[string]interface{})
+		previous[content["username"].(string)] = content
+	}
+
+	desired := d.Get("users").([]interface{})
+	results := make([]interface{}, 0, len(desired)+len(previous))
+	changes := make([]func() map[string]interface{}, 0, len(desired)+len(previous))
+	applied := make([][]interface{}, len(desired))
+	for i, item := range desired {
+		i := i
+		content := item.(map[string]interface{})
+		username := content["username"].(string)
+		var prevteams []interface{}
+		if prev, ok := previous[username]; ok {
+			prevteams = prev["teams"].(*schema.Set).List()
+			delete(previous, username)
+		}
+		usr, exists := live[username]
+		changes = append(changes, func() map[string]interface{} {
+			var existing *user.User
+			if exists {
+				existing = &usr
+			}
+			result, teams := applyUserBulkItem(ctx, &pco, orgid, content, existing, prevteams)
+			applied[i] = teams
+			return result
+		})
+	}
+	deleteRemoved := d.Get("delete_removed_users").(bool)
+	removed := make([]string, 0, len(previous))
+	for username := range previous {
+		removed = append(removed, username)
+	}
+	sort.Strings(removed)
+	for _, username := range removed {
+		username := username
+		usr, exists := live[username]
+		changes = append(changes, func() map[string]interface{} {
+			result := newUsersBulkResult(username, usr.GetId(), "released", "")
+			if !exists || !deleteRemoved {
+				return result
+			}
+			authctx := getUserAuthCtx(ctx, &pco)
+			httpr, err := pco.userclient.DefaultApi.OrganizationsOrgIdUsersUserIdDelete(authctx, orgid, usr.GetId()).Execute()
+			if errDiags := changeRequestDiags(httpr, err, "Unable to delete user "+username); errDiags.HasError() {
+				result["status"] = "failed"
+				result["error"] = errDiags[0].Detail
+				return result
+			}
+			result["status"] = "deleted"
+			return result
+		})
+	}
+
+	outcomes := make([]map[string]interface{}, len(changes))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, usersBulkBatchSize)
+	for i, change := range changes {
+		wg.Add(1)
+		go func(i int, change func() map[string]interface{}) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			outcomes[i] = change()
+		}(i, change)
+	}
+	wg.Wait()
+
+	for _, result := range outcomes {
+		if result["status"] == "failed" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unable to provision user " + result["username"].(string),
+				Detail:   result["error"].(string),
+			})
+		}
+		results = append(results, result)
+	}
+	if err := d.Set("results", results); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set users results of org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	//the state keeps the teams actually applied to each user, the failed memberships changes are applied again on the next apply
+	users := make([]interface{}, len(desired))
+	for i, item := range desired {
+		content := make(map[string]interface{})
+		for k, v := range item.(map[string]interface{}) {
+			content[k] = v
+		}
+		content["teams"] = schema.NewSet(schema.HashString, applied[i])
+		users[i] = content
+	}
+	if err := d.Set("users", users); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set users of org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+/*
+ Creates or updates the given user of the resource and applies its team memberships.
+ existing is the live user, nil when the user doesn't exist yet, prevteams are the teams of the user in the previous apply.
+ Returns the outcome of the user and the teams of the user once the memberships changes that succeeded are applied
+*/
+func applyUserBulkItem(ctx context.Context, pco *ProviderConfOutput, orgid string, content map[string]interface{}, existing *user.User, prevteams []interface{}) (map[string]interface{}, []interface{}) {
+	authctx := getUserAuthCtx(ctx, pco)
+	username := content["username"].(string)
+	result := newUsersBulkResult(username, "", "unchanged", "")
+	phonenumber := content["phone_number"].(string)
+
+	var userid string
+	if existing == nil {
+		body := user.NewUserPostBodyWithDefaults()
+		body.SetUsername(username)
+		body.SetFirstName(content["first_name"].(string))
+		body.SetLastName(content["last_name"].(string))
+		body.SetEmail(content["email"].(string))
+		if phonenumber != "" {
+			body.SetPhoneNumber(phonenumber)
+		}
+		if password := content["password"].(string); password != "" {
+			body.SetPassword(password)
+		}
+		res, httpr, err := pco.userclient.DefaultApi.OrganizationsOrgIdUsersPost(authctx, orgid).UserPostBody(*body).Execute()
+		if errDiags := changeRequestDiags(httpr, err, "Unable to create user "+username); errDiags.HasError() {
+			result["status"] = "failed"
+			result["error"] = errDiags[0].Detail
+			return result, prevteams
+		}
+		userid = res.GetId()
+		result["status"] = "created"
+	} else {
+		userid = existing.GetId()
+		if existing.GetFirstName() != content["first_name"].(string) || existing.GetLastName() != content["last_name"].(string) ||
+			existing.GetEmail() != content["email"].(string) || (phonenumber != "" && existing.GetPhoneNumber() != phonenumber) {
+			body := user.NewUserPutBodyWithDefaults()
+			body.SetUsername(username)
+			body.SetFirstName(content["first_name"].(string))
+			body.SetLastName(content["last_name"].(string))
+			body.SetEmail(content["email"].(string))
+			// an empty phone number means it is not managed
+			if phonenumber != "" {
+				body.SetPhoneNumber(phonenumber)
+			}
+			_, httpr, err := pco.userclient.DefaultApi.OrganizationsOrgIdUsersUserIdPut(authctx, orgid, userid).UserPutBody(*body).Execute()
+			if errDiags := changeRequestDiags(httpr, err, "Unable to update user "+username); errDiags.HasError() {
+				result["user_id"] = userid
+				result["status"] = "failed"
+				result["error"] = errDiags[0].Detail
+				return result, prevteams
+			}
+			result["status"] = "updated"
+		}
+	}
+	result["user_id"] = userid
+
+	//team memberships, only the teams listed now or in the previous apply are changed
+	teams := content["teams"].(*schema.Set)
+	prev := schema.NewSet(schema.HashString, prevteams)
+	current := schema.NewSet(schema.HashString, prevteams)
+	membersauthctx := getTeamMembersAuthCtx(ctx, pco)
+	for _, teamid := range teams.Difference(prev).List() {
+		body := team_members.NewTeamMemberPutBodyWithDefaults()
+		body.SetMembershipType("member")
+		httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersUserIdPut(membersauthctx, orgid, teamid.(string), userid).TeamMemberPutBody(*body).Execute()
+		if errDiags := changeRequestDiags(httpr, err, "Unable to add user "+username+" to team "+teamid.(string)); errDiags.HasError() {
+			result["status"] = "failed"
+			result["error"] = errDiags[0].Summary + ": " + errDiags[0].Detail
+			return result, current.List()
+		}
+		current.Add(teamid)
+		if result["status"] == "unchanged" {
+			result["status"] = "updated"
+		}
+	}
+	for _, teamid := range prev.Difference(teams).List() {
+		httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersUserIdDelete(membersauthctx, orgid, teamid.(string), userid).Execute()
+		if errDiags := changeRequestDiags(httpr, err, "Unable to remove user "+username+" from team "+teamid.(string)); errDiags.HasError() {
+			result["status"] = "failed"
+			result["error"] = errDiags[0].Summary + ": " + errDiags[0].Detail
+			return result, current.List()
+		}
+		current.Remove(teamid)
+		if result["status"] == "unchanged" {
+			result["status"] = "updated"
+		}
+	}
+
+	return result, current.List()
+}
+
+/*
+ Returns true when the given username is one of the users in the state of the resource
+*/
+func isUsersBulkManagedUser(d *schema.ResourceData, username string) bool {
+	old, _ := d.GetChange("users")
+	for _, item := range old.([]interface{}) {
+		if item.(map[string]interface{})["username"] == username {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ Returns the users of the given org indexed by username
+*/
+func getUsersByUsername(ctx context.Context, pco *ProviderConfOutput, orgid string) (map[string]user.User, diag.Diagnostics) {
+	data, diags := searchUsers(ctx, pco, orgid, func(usr *user.User) bool {
+		return true
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	users := make(map[string]user.User)
+	for _, usr := range data {
+		users[usr.GetUsername()] = usr
+	}
+	return users, diags
+}
+
+func newUsersBulkResult(username string, userid string, status string, err string) map[string]interface{} {
+	result := make(map[string]interface{})
+	result["username"] = username
+	result["user_id"] = userid
+	result["status"] = status
+	result["error"] = err
+	return result
+}
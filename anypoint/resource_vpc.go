@@ -124,6 +124,12 @@ func resourceVPC() *schema.Resource {
 					},
 				},
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true the vpc cannot be deleted",
+			},
 		},
 	}
 }
@@ -249,6 +255,15 @@ func resourceVPCDelete(ctx context.Context, d *schema.ResourceData, m interface{
 
 	authctx := getVPCAuthCtx(ctx, &pco)
 
+	if d.Get("deletion_protection").(bool) {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to Delete VPC",
+			Detail:   "Deletion protection is enabled for VPC " + vpcid + ", set deletion_protection to false and apply before deleting it.",
+		})
+		return diags
+	}
+
 	httpr, err := pco.vpcclient.DefaultApi.OrganizationsOrgIdVpcsVpcIdDelete(authctx, orgid, vpcid).Execute()
 	if err != nil {
 		var details string
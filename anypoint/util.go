@@ -1,6 +1,23 @@
 package anypoint
 
-import "reflect"
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maximum number of pages requested at the same time when loading all the pages of a collection
+const maxConcurrentPageRequests = 4
+
+// page size used when loading all the pages of a collection without search parameters
+const defaultPageSize = 200
 
 func IsString(v interface{}) bool {
 	return reflect.TypeOf(v) == reflect.TypeOf("")
@@ -33,3 +50,146 @@ func ListInterface2ListStrings(array []interface{}) []string {
 	}
 	return list
 }
+
+/*
+ Loads the pages of a paginated collection starting at the given offset.
+ fetchPage requests the page at the given offset and returns the items of the page, their number, the total number of items
+ and the response of the request. Failed requests are reported with the given summary.
+ The first page is loaded alone to learn the total, when allPages is true the following ones are then loaded concurrently.
+ Returns the items of each loaded page in ascending offset order and the total number of items
+*/
+func fetchAllPages(offset int32, limit int32, allPages bool, summary string, fetchPage func(offset int32, limit int32) (interface{}, int, int32, *http.Response, error)) ([]interface{}, int32, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var mu sync.Mutex
+	var total int32
+	pages := make(map[int32]interface{})
+
+	load := func(offset int32, limit int32) (int, diag.Diagnostics) {
+		var diags diag.Diagnostics
+		page, count, t, httpr, err := fetchPage(offset, limit)
+		if err != nil {
+			var details string
+			if httpr != nil {
+				b, _ := ioutil.ReadAll(httpr.Body)
+				details = string(b)
+			} else {
+				details = err.Error()
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  summary,
+				Detail:   details,
+			})
+			return 0, diags
+		}
+		if httpr != nil {
+			defer httpr.Body.Close()
+		}
+		mu.Lock()
+		pages[offset] = page
+		total = t
+		mu.Unlock()
+		return count, diags
+	}
+
+	count, errDiags := load(offset, limit)
+	if errDiags.HasError() {
+		diags = append(diags, errDiags...)
+		return nil, 0, diags
+	}
+	offsets := []int32{offset}
+	if allPages && count > 0 {
+		// the server may return less items than requested per page
+		step := limit
+		if int32(count) < step {
+			step = int32(count)
+		}
+		for o := offset + step; o < total; o += step {
+			offsets = append(offsets, o)
+		}
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, maxConcurrentPageRequests)
+		for _, o := range offsets[1:] {
+			wg.Add(1)
+			go func(o int32) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				if _, errDiags := load(o, step); errDiags.HasError() {
+					mu.Lock()
+					diags = append(diags, errDiags...)
+					mu.Unlock()
+				}
+			}(o)
+		}
+		wg.Wait()
+		if diags.HasError() {
+			return nil, 0, diags
+		}
+	}
+
+	res := make([]interface{}, len(offsets))
+	for i, o := range offsets {
+		res[i] = pages[o]
+	}
+	return res, total, diags
+}
+
+/*
+ Returns the offset and limit set in the given search parameters, or the default ones
+*/
+func getPageOpts(params *schema.Set) (int32, int32) {
+	offset := int32(0)
+	limit := int32(defaultPageSize)
+	if params.Len() > 0 {
+		opts := params.List()[0].(map[string]interface{})
+		if v, ok := opts["offset"]; ok {
+			offset = int32(v.(int))
+		}
+		if v, ok := opts["limit"]; ok && v.(int) > 0 {
+			limit = int32(v.(int))
+		}
+	}
+	return offset, limit
+}
+
+/*
+ Returns a stable data source id made of the given scope (usually the org id) and a hash of the given search parameters.
+ The same parameters always give the same id so that reading the data source again doesn't show it as changed
+*/
+func getDataSourceId(scope string, params ...interface{}) string {
+	var b strings.Builder
+	for _, p := range params {
+		if set, ok := p.(*schema.Set); ok {
+			p = set.List()
+		}
+		fmt.Fprintf(&b, "%v;", p)
+	}
+	return scope + "/" + strconv.Itoa(schema.HashString(b.String()))
+}
+
+/*
+ Returns the error diagnostics of a change request (create, update or delete) having the given summary,
+ the details are read from the response body when there is one
+*/
+func changeRequestDiags(httpr *http.Response, err error, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if err != nil {
+		var details string
+		if httpr != nil {
+			b, _ := ioutil.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	return diags
+}
@@ -0,0 +1,93 @@
+package anypoint
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestFetchAllPages(t *testing.T) {
+	cases := []struct {
+		name     string
+		offset   int32
+		limit    int32
+		allPages bool
+		total    int
+		maxPage  int // page size cap of the server, 0 for none
+		requests int
+	}{
+		{name: "no items", limit: 10, allPages: true, total: 0, requests: 1},
+		{name: "exactly one page", limit: 10, allPages: true, total: 10, requests: 1},
+		{name: "one page plus one item", limit: 10, allPages: true, total: 11, requests: 2},
+		{name: "total not a multiple of the page size", limit: 10, allPages: true, total: 25, requests: 3},
+		{name: "server page size smaller than the limit", limit: 10, allPages: true, total: 25, maxPage: 4, requests: 7},
+		{name: "starting offset", offset: 5, limit: 10, allPages: true, total: 25, requests: 2},
+		{name: "first page only", limit: 10, allPages: false, total: 25, requests: 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := 0
+			pages, total, diags := fetchAllPages(c.offset, c.limit, c.allPages, "Unable to get items", func(offset int32, limit int32) (interface{}, int, int32, *http.Response, error) {
+				mu.Lock()
+				requests++
+				mu.Unlock()
+				size := int(limit)
+				if c.maxPage > 0 && size > c.maxPage {
+					size = c.maxPage
+				}
+				page := make([]int, 0, size)
+				for i := int(offset); i < c.total && len(page) < size; i++ {
+					page = append(page, i)
+				}
+				return page, len(page), int32(c.total), nil, nil
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if total != int32(c.total) {
+				t.Errorf("expected total %d, got %d", c.total, total)
+			}
+			if requests != c.requests {
+				t.Errorf("expected %d requests, got %d", c.requests, requests)
+			}
+
+			items := make([]int, 0)
+			for _, page := range pages {
+				items = append(items, page.([]int)...)
+			}
+			expected := make([]int, 0)
+			for i := int(c.offset); i < c.total; i++ {
+				if !c.allPages && len(expected) == int(c.limit) {
+					break
+				}
+				expected = append(expected, i)
+			}
+			if !reflect.DeepEqual(items, expected) {
+				t.Errorf("expected items %v, got %v", expected, items)
+			}
+		})
+	}
+}
+
+func TestFetchAllPagesError(t *testing.T) {
+	pages, _, diags := fetchAllPages(0, 10, true, "Unable to get items", func(offset int32, limit int32) (interface{}, int, int32, *http.Response, error) {
+		if offset == 10 {
+			return nil, 0, 0, nil, errors.New("page unavailable")
+		}
+		page := make([]int, limit)
+		return page, len(page), 30, nil, nil
+	})
+	if !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	if pages != nil {
+		t.Errorf("expected no pages, got %v", pages)
+	}
+	if diags[0].Summary != "Unable to get items" || diags[0].Detail != "page unavailable" {
+		t.Errorf("unexpected diagnostic %+v", diags[0])
+	}
+}
@@ -0,0 +1,9 @@
+data "anypoint_envs" "sandboxes" {
+  org_id     = "xxxx-xxx-xxx"   # the business group id
+  type       = "sandbox"        # optional filter on the environment type
+  name_regex = "^DEV|QA$"       # optional filter on the environment name
+}
+
+output "sandbox_ids" {
+  value = data.anypoint_envs.sandboxes.by_name
+}
\ No newline at end of file
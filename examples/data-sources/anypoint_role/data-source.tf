@@ -0,0 +1,7 @@
+data "anypoint_role" "admin" {
+  name = "Access Controls Admin"
+}
+
+output "admin_role_id" {
+  value = data.anypoint_role.admin.role_id
+}
@@ -0,0 +1,10 @@
+data "anypoint_rolegroups_to_teams" "migration" {
+  org_id           = var.root_org
+  parent_team_id   = var.root_team      # the teams are created under this team
+  team_name_prefix = ""                 # optional, added to the rolegroup names to name the teams
+}
+
+# terraform output -raw teams_config > teams.tf
+output "teams_config" {
+  value = data.anypoint_rolegroups_to_teams.migration.config
+}
@@ -1,4 +1,11 @@
-resource "anypoint_team" "team" {
+data "anypoint_team" "team" {
   org_id = var.root_org
   id     = "TEAM_ID"
 }
+
+# or look the team up by its name
+data "anypoint_team" "team_by_name" {
+  org_id         = var.root_org
+  team_name      = "Terraform Provider Team"
+  parent_team_id = var.root_team      # optional, restricts the lookup to the children of this team
+}
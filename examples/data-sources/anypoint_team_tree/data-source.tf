@@ -0,0 +1,8 @@
+data "anypoint_team_tree" "tree" {
+  org_id       = var.root_org
+  root_team_id = var.root_team      # optional, by default the whole hierarchy of the org is returned
+}
+
+output "tree" {
+  value = jsondecode(data.anypoint_team_tree.tree.tree)
+}
@@ -3,6 +3,11 @@
    id     = "YOUR_USER_ID"
  }
 
+ data "anypoint_user" "by_username" {
+   org_id   = "YOUR_ORG_ID"
+   username = "YOUR_USERNAME"
+ }
+
  output "user" {
    value = data.anypoint_user.user
  }
\ No newline at end of file
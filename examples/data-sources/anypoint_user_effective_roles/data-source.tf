@@ -0,0 +1,9 @@
+data "anypoint_user_effective_roles" "audit" {
+  org_id  = var.root_org
+  user_id = anypoint_user.user.id
+}
+
+# the roles granted through the identity provider groups
+output "idp_roles" {
+  value = [for r in data.anypoint_user_effective_roles.audit.roles : r.name if r.source == "external_group"]
+}
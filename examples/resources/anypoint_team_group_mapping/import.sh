@@ -0,0 +1 @@
+terraform import anypoint_team_group_mapping.employees ORG_ID/TEAM_ID/PROVIDER_ID/EXTERNAL_GROUP_NAME
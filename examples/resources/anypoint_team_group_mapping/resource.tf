@@ -0,0 +1,15 @@
+resource "anypoint_team_group_mapping" "employees" {
+  org_id              = var.root_org
+  team_id             = anypoint_team.team.id
+  provider_id         = "pr01"          #the saml identity provider id
+  external_group_name = "gr_name01"     #the group name in the IDP side
+  membership_type     = "maintainer"    #enum : member or maintainer
+}
+
+resource "anypoint_team_group_mapping" "contractors" {
+  org_id              = var.root_org
+  team_id             = anypoint_team.team.id
+  provider_id         = "pr02"          #the oidc identity provider id
+  external_group_name = "gr_name_pr02_01"
+  membership_type     = "member"
+}
@@ -0,0 +1,2 @@
+# imports the direct members of the team TEAM_ID of the business group ORG_ID
+terraform import anypoint_team_members.members ORG_ID/TEAM_ID
@@ -0,0 +1,13 @@
+resource "anypoint_team_members" "members" {
+  org_id = var.root_org
+  team_id = anypoint_team.team.id
+
+  members {
+    user_id = anypoint_user.user.id
+    membership_type = "maintainer"
+  }
+
+  members {
+    user_id = anypoint_user.user2.id
+  }
+}
@@ -0,0 +1,6 @@
+# a role of the business group of the team
+terraform import anypoint_team_role.admin ORG_ID/TEAM_ID/ROLE_ID
+# a role of another business group
+terraform import anypoint_team_role.sub_org_admin ORG_ID/TEAM_ID/ROLE_ID/ROLE_ORG_ID
+# an environment scoped role
+terraform import anypoint_team_role.api_manager ORG_ID/TEAM_ID/ROLE_ID/ROLE_ORG_ID/ENV_ID
@@ -0,0 +1,13 @@
+resource "anypoint_team_role" "api_manager" {
+  org_id  = var.root_org
+  team_id = anypoint_team.team.id
+  role_id = data.anypoint_role.manage_apis.role_id
+  env_id  = anypoint_env.env.id       # only for environment scoped roles
+}
+
+resource "anypoint_team_role" "sub_org_admin" {
+  org_id      = var.root_org
+  team_id     = anypoint_team.team.id
+  role_id     = data.anypoint_role.org_admin.role_id
+  role_org_id = anypoint_bg.sub_org.id  # the role applies to a sub business group
+}
@@ -4,10 +4,13 @@ resource "anypoint_team_roles" "roles" {
   
   # you can check the role data-source to get roles dynamically
   roles {
-    role_id = "42ea6892-f95c-4d1b-ab48-687b1f6632fc"    # Access Controls Admin
-    context_params = {
-      org = anypoint_bg.bg.id           # the business group to which the role applies
-      envId = anypoint_env.env.id       # if the role spans environments, the environment id
-    }
+    role_id = data.anypoint_role.admin.role_id    # Access Controls Admin
+    org_id  = anypoint_bg.bg.id                   # the business group to which the role applies
   }
-}
\ No newline at end of file
+
+  roles {
+    role_id = "05e01150-dcfd-45f2-8a74-1115ce5c068c"    # Administrate Flow
+    org_id  = anypoint_bg.bg.id                         # the business group to which the role applies
+    env_id  = anypoint_env.env.id                       # if the role is environment scoped, the environment id
+  }
+}
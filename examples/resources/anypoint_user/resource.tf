@@ -6,6 +6,7 @@ resource "anypoint_user" "user" {
   email = "terraform@provider.com"
   phone_number = "0756224452"
   password = "my_super_secret_pwd"
+  password_version = "1"              # change it to send a new password
 }
 
 output "user" {
@@ -0,0 +1,29 @@
+# users.csv
+# username,first_name,last_name,email,phone_number,teams
+# jdoe,John,Doe,jdoe@example.com,0600000000,TEAM_ID_1;TEAM_ID_2
+locals {
+  users = csvdecode(file("${path.module}/users.csv"))
+}
+
+resource "anypoint_users_bulk" "business_unit" {
+  org_id = var.root_org
+
+  dynamic "users" {
+    for_each = local.users
+    content {
+      username     = users.value.username
+      first_name   = users.value.first_name
+      last_name    = users.value.last_name
+      email        = users.value.email
+      phone_number = users.value.phone_number
+      password     = var.initial_password
+      teams        = compact(split(";", users.value.teams))
+    }
+  }
+
+  delete_removed_users = false
+}
+
+output "failed_users" {
+  value = [for r in anypoint_users_bulk.business_unit.results : r if r.status == "failed"]
+}